@@ -0,0 +1,90 @@
+// Команда migrate применяет, откатывает и показывает миграции без запуска HTTP-сервера.
+//
+//	migrate up          — применить все новые миграции
+//	migrate down [N]    — откатить последние N миграций (по умолчанию 1)
+//	migrate status      — показать состояние миграций
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/zenrush/backend/internal/db"
+	"github.com/zenrush/backend/internal/migrate"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	if err := db.Open(); err != nil {
+		log.Fatalf("DB init error: %v", err)
+	}
+	sqlDB, err := db.DB.DB()
+	if err != nil {
+		log.Fatalf("DB init error: %v", err)
+	}
+	m, err := migrate.New(sqlDB)
+	if err != nil {
+		log.Fatalf("load migrations: %v", err)
+	}
+	ctx := context.Background()
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, mig := range applied {
+			fmt.Printf("applied %04d_%s\n", mig.Version, mig.Name)
+		}
+		if len(applied) == 0 {
+			fmt.Println("nothing to apply")
+		}
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				usage()
+			}
+		}
+		reverted, err := m.Down(ctx, steps)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, mig := range reverted {
+			fmt.Printf("reverted %04d_%s\n", mig.Version, mig.Name)
+		}
+		if len(reverted) == 0 {
+			fmt.Println("nothing to revert")
+		}
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, s := range statuses {
+			state, at := "pending", ""
+			if s.Applied {
+				state, at = "applied", s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, at)
+		}
+		w.Flush()
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [N] | status")
+	os.Exit(2)
+}
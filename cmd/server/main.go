@@ -1,64 +1,111 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
 	"github.com/zenrush/backend/internal/db"
-	"github.com/zenrush/backend/internal/handlers"
-	"github.com/zenrush/backend/internal/middleware"
+	"github.com/zenrush/backend/internal/localtime"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/repository/memory"
+	"github.com/zenrush/backend/internal/repository/postgres"
+	"github.com/zenrush/backend/internal/retention"
+	"github.com/zenrush/backend/internal/seed"
+	"github.com/zenrush/backend/internal/server"
+	"github.com/zenrush/backend/internal/weather"
 )
 
 func main() {
-	if err := db.Init(); err != nil {
+	store, err := openStore()
+	if err != nil {
 		log.Fatalf("DB init error: %v", err)
 	}
+	timezone := defaultTimezone()
+	if err := seed.Run(context.Background(), store, timezone); err != nil {
+		log.Fatalf("Seed error: %v", err)
+	}
 
-	r := gin.Default()
-
-	// Настройка CORS для фронтенда
-	config := cors.DefaultConfig()
-	config.AllowOrigins = []string{"http://127.0.0.1:5500", "http://localhost:5173", "http://localhost:3000", "http://localhost:4173"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization"}
-	config.AllowCredentials = true
-	r.Use(cors.New(config))
+	if maxAge := historyRetention(); maxAge > 0 {
+		go retention.PruneHistory(context.Background(), store.History, maxAge, time.Hour)
+	}
 
-	api := r.Group("/api")
-	{
-		auth := api.Group("/auth")
-		auth.POST("/register", handlers.Register)
-		auth.POST("/login", handlers.Login)
+	r := server.NewRouter(store, weatherProvider(), timezone)
 
-		activities := api.Group("/activities")
-		activities.Use(middleware.JWTAuth())
-		activities.GET("", handlers.ListActivities)
-		activities.POST("", handlers.CreateActivity)
-		activities.GET(":id", handlers.GetActivity)
-		activities.PUT(":id", handlers.UpdateActivity)
-		activities.DELETE(":id", handlers.DeleteActivity)
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = "8080"
+	}
+	r.Run(":" + port)
+}
 
-		favorites := api.Group("/favorites")
-		favorites.Use(middleware.JWTAuth())
-		favorites.GET("", handlers.ListFavorites)
-		favorites.POST(":activity_id", handlers.AddFavorite)
-		favorites.DELETE(":activity_id", handlers.RemoveFavorite)
+// openStore выбирает хранилище по переменной STORAGE: postgres (по умолчанию) или memory
+func openStore() (*repository.Store, error) {
+	switch storage := os.Getenv("STORAGE"); storage {
+	case "", "postgres":
+		if err := db.Init(); err != nil {
+			return nil, err
+		}
+		return postgres.NewStore(db.DB), nil
+	case "memory":
+		log.Println("Использую in-memory хранилище, данные не сохраняются между перезапусками")
+		return memory.NewStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown STORAGE %q", storage)
+	}
+}
 
-		history := api.Group("/history")
-		history.Use(middleware.JWTAuth())
-		history.GET("", handlers.ListHistory)
-		history.POST(":activity_id", handlers.AddHistory)
+// weatherProvider настраивает источник погоды для weather=auto.
+// WEATHER_BASE_URL — адрес сервиса в формате wttr.in (по умолчанию https://wttr.in),
+// пустое значение отключает определение погоды; WEATHER_CACHE_TTL — сколько помнить
+// погоду по городу (по умолчанию 30m).
+func weatherProvider() weather.Provider {
+	baseURL, ok := os.LookupEnv("WEATHER_BASE_URL")
+	if !ok {
+		baseURL = "https://wttr.in"
+	}
+	if baseURL == "" {
+		log.Println("WEATHER_BASE_URL пуст, weather=auto не фильтрует по погоде")
+		return nil
+	}
+	ttl := 30 * time.Minute
+	if v := os.Getenv("WEATHER_CACHE_TTL"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			log.Fatalf("invalid WEATHER_CACHE_TTL: %v", err)
+		}
+		ttl = d
+	}
+	return weather.NewCachedProvider(weather.NewHTTPProvider(baseURL, nil), ttl)
+}
 
-		// --- Mood stats ---
-		api.POST("/mood-stats", middleware.JWTAuth(), handlers.SaveOrUpdateMoodStat)
-		api.GET("/users/me/mood-stats", middleware.JWTAuth(), handlers.GetMoodStats)
+// defaultTimezone — часовой пояс для границ дня у пользователей, которые свой не указали:
+// DEFAULT_TIMEZONE (IANA, например Europe/Moscow), по умолчанию UTC
+func defaultTimezone() *time.Location {
+	name := os.Getenv("DEFAULT_TIMEZONE")
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := localtime.Load(name)
+	if err != nil {
+		log.Fatalf("invalid DEFAULT_TIMEZONE %q", name)
 	}
+	return loc
+}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+// historyRetention — сколько хранить историю просмотров: HISTORY_RETENTION_DAYS дней,
+// пусто или 0 — бессрочно
+func historyRetention() time.Duration {
+	v := os.Getenv("HISTORY_RETENTION_DAYS")
+	if v == "" {
+		return 0
 	}
-	r.Run(":" + port)
+	days, err := strconv.Atoi(v)
+	if err != nil || days < 0 {
+		log.Fatalf("invalid HISTORY_RETENTION_DAYS %q", v)
+	}
+	return time.Duration(days) * 24 * time.Hour
 }
@@ -0,0 +1,164 @@
+// Package export выгружает данные пользователя в CSV, JSON и ZIP.
+//
+// Данные не собираются в памяти целиком: каждый набор отдаёт строки по одной
+// через Dataset.Rows, а они сразу пишутся в выходной поток.
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// bom — метка порядка байтов UTF-8, чтобы Excel открывал кириллицу без настроек
+const bom = "\xEF\xBB\xBF"
+
+// Dataset — один набор данных: таблица в CSV, массив объектов в JSON
+type Dataset struct {
+	Name    string
+	Columns []string
+	// Rows вызывает emit для каждой строки; значения идут в порядке Columns
+	Rows func(ctx context.Context, emit func(values ...any) error) error
+}
+
+// WriteCSV пишет один набор как CSV с заголовком
+func WriteCSV(ctx context.Context, w io.Writer, d Dataset) error {
+	if _, err := io.WriteString(w, bom); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(d.Columns); err != nil {
+		return err
+	}
+	record := make([]string, len(d.Columns))
+	err := d.Rows(ctx, func(values ...any) error {
+		for i := range record {
+			record[i] = text(values[i])
+		}
+		return cw.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON пишет наборы одним объектом: {"имя набора": [{колонка: значение}, ...], ...}
+func WriteJSON(ctx context.Context, w io.Writer, datasets []Dataset) error {
+	if _, err := io.WriteString(w, "{"); err != nil {
+		return err
+	}
+	for i, d := range datasets {
+		if i > 0 {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		if err := writeJSONArray(ctx, w, d); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "}\n")
+	return err
+}
+
+func writeJSONArray(ctx context.Context, w io.Writer, d Dataset) error {
+	name, _ := json.Marshal(d.Name)
+	if _, err := fmt.Fprintf(w, "%s:[", name); err != nil {
+		return err
+	}
+	// Ключи объекта пишутся по порядку колонок, поэтому собираем его вручную
+	keys := make([][]byte, len(d.Columns))
+	for i, c := range d.Columns {
+		keys[i], _ = json.Marshal(c)
+	}
+	first := true
+	err := d.Rows(ctx, func(values ...any) error {
+		var b strings.Builder
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		b.WriteByte('{')
+		for i, v := range values {
+			value, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.Write(keys[i])
+			b.WriteByte(':')
+			b.Write(value)
+		}
+		b.WriteByte('}')
+		_, err := io.WriteString(w, b.String())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]")
+	return err
+}
+
+// WriteZip пишет архив: по CSV-файлу на набор и all.json со всеми наборами
+func WriteZip(ctx context.Context, w io.Writer, datasets []Dataset) error {
+	zw := zip.NewWriter(w)
+	for _, d := range datasets {
+		f, err := zw.Create(d.Name + ".csv")
+		if err != nil {
+			return err
+		}
+		if err := WriteCSV(ctx, f, d); err != nil {
+			return err
+		}
+	}
+	f, err := zw.Create("all.json")
+	if err != nil {
+		return err
+	}
+	if err := WriteJSON(ctx, f, datasets); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// text — значение ячейки CSV: время в RFC 3339, списки через «; », nil — пусто
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	case *int:
+		if v == nil {
+			return ""
+		}
+		return strconv.Itoa(*v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, "; ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
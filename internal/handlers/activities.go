@@ -1,74 +1,110 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/zenrush/backend/internal/db"
 	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
 	"github.com/zenrush/backend/internal/utils"
-	"gorm.io/gorm/clause"
+	"github.com/zenrush/backend/internal/weather"
 )
 
-// Получить список всех активностей (с фильтрами)
-func ListActivities(c *gin.Context) {
-	var activities []models.Activity
-	q := db.DB.Model(&models.Activity{})
+type ActivityHandler struct {
+	filterSource
+	activities   repository.ActivityRepository
+	searchEvents repository.SearchEventRepository
+}
 
-	if minBudget := c.Query("min_budget"); minBudget != "" {
-		if v, err := strconv.Atoi(minBudget); err == nil {
-			q = q.Where("budget >= ?", v)
-		}
+func NewActivityHandler(
+	activities repository.ActivityRepository,
+	searchEvents repository.SearchEventRepository,
+	users repository.UserRepository,
+	forecast weather.Provider,
+	moods repository.MoodRepository,
+) *ActivityHandler {
+	return &ActivityHandler{
+		filterSource: filterSource{users: users, forecast: forecast, moods: moods},
+		activities:   activities,
+		searchEvents: searchEvents,
 	}
-	if maxBudget := c.Query("max_budget"); maxBudget != "" {
-		if v, err := strconv.Atoi(maxBudget); err == nil {
-			q = q.Where("budget <= ?", v)
-		}
+}
+
+// Получить список всех активностей (с фильтрами)
+func (h *ActivityHandler) List(c *gin.Context) {
+	filter, ok := h.activityFilter(c)
+	if !ok {
+		return
 	}
-	if timeParam := c.Query("time"); timeParam != "" {
-		if v, err := strconv.Atoi(timeParam); err == nil {
-			q = q.Where("time = ?", v)
-		}
+	var def repository.ActivitySort
+	if filter.Query != "" {
+		def = repository.SortByRelevance
 	}
-	if mood := c.Query("mood"); mood != "" {
-		q = q.Where("? = ANY(moods)", mood)
-		// --- Сохраняем настроение пользователя в статистику ---
-		userID, exists := c.Get("user_id")
-		if exists {
-			// Upsert в mood_stats
-			db.DB.Clauses(clause.OnConflict{
-				Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
-				DoUpdates: clause.AssignmentColumns([]string{"mood"}),
-			}).Create(&models.MoodStat{
-				UserID: userID.(uint),
-				Date:   time.Now().Truncate(24 * time.Hour),
-				Mood:   mood,
-			})
-		}
+	order, ok := parseActivityOrder(c, def)
+	if !ok {
+		return
 	}
-	if weather := c.Query("weather"); weather != "" {
-		q = q.Where("weather = ?", weather)
+	page, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+
+	activities, total, err := h.activities.List(c.Request.Context(), filter, order, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
 	}
-	if peopleCount := c.Query("people_count"); peopleCount != "" {
-		if v, err := strconv.Atoi(peopleCount); err == nil {
-			q = q.Where("people_count = ?", v)
+	// Поиск запоминаем один раз, а не на каждой странице
+	if page.Offset == 0 && searchIntent(filter) {
+		event := models.SearchEvent{
+			UserID:      c.GetUint("user_id"),
+			Query:       filter.Query,
+			Moods:       filter.Moods,
+			Params:      c.Request.URL.RawQuery,
+			ResultCount: total,
+		}
+		if err := h.searchEvents.Add(c.Request.Context(), &event); err != nil {
+			log.Printf("save search event: %v", err)
 		}
 	}
+	setPageHeaders(c, page, total)
+	c.JSON(http.StatusOK, activities)
+}
 
-	if err := q.Find(&activities).Error; err != nil {
+// searchIntent — задан ли хоть один фильтр, то есть пользователь что-то ищет
+func searchIntent(f repository.ActivityFilter) bool {
+	return f.Query != "" || len(f.Moods) > 0 || len(f.Weather) > 0 ||
+		f.MinBudget != nil || f.MaxBudget != nil || f.MinTime != nil || f.MaxTime != nil || f.PeopleCount != nil
+}
+
+// GET /api/activities/suggest?q=прог
+// Автодополнение по названию: совпадения по началу слов и варианты с опечатками
+func (h *ActivityHandler) Suggest(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > 20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit param"})
+		return
+	}
+	suggestions, err := h.activities.Suggest(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
-	c.JSON(http.StatusOK, activities)
+	c.JSON(http.StatusOK, suggestions)
 }
 
 // Получить одну активность по id
-func GetActivity(c *gin.Context) {
-	var activity models.Activity
-	id := c.Param("id")
-	if err := db.DB.First(&activity, id).Error; err != nil {
+func (h *ActivityHandler) Get(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	activity, err := h.activities.Get(c.Request.Context(), id)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 		return
 	}
@@ -76,7 +112,7 @@ func GetActivity(c *gin.Context) {
 }
 
 // Создать новую активность (только для модератора или админа)
-func CreateActivity(c *gin.Context) {
+func (h *ActivityHandler) Create(c *gin.Context) {
 	if !utils.IsModeratorOrAdmin(c) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
@@ -86,7 +122,18 @@ func CreateActivity(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
-	if err := db.DB.Create(&req).Error; err != nil {
+	if !normalizeActivity(&req) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	slugs, ok := h.moodSlugs(c, req.Moods)
+	if !ok {
+		return
+	}
+	req.Moods = slugs
+	// Рейтинг складывается только из отзывов
+	req.RatingAvg, req.RatingCount = 0, 0
+	if err := h.activities.Create(c.Request.Context(), &req); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
@@ -94,14 +141,18 @@ func CreateActivity(c *gin.Context) {
 }
 
 // Обновить существующую активность (только для модератора или админа)
-func UpdateActivity(c *gin.Context) {
+func (h *ActivityHandler) Update(c *gin.Context) {
 	if !utils.IsModeratorOrAdmin(c) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
-	id := c.Param("id")
-	var activity models.Activity
-	if err := db.DB.First(&activity, id).Error; err != nil {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	activity, err := h.activities.Get(c.Request.Context(), id)
+	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
 		return
 	}
@@ -110,14 +161,29 @@ func UpdateActivity(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
+	if !normalizeActivity(&req) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	slugs, ok := h.moodSlugs(c, req.Moods)
+	if !ok {
+		return
+	}
+	req.Moods = slugs
 	activity.Name = req.Name
 	activity.Description = req.Description
 	activity.Budget = req.Budget
 	activity.Time = req.Time
 	activity.Weather = req.Weather
-	activity.PeopleCount = req.PeopleCount
+	activity.MinPeople = req.MinPeople
+	activity.MaxPeople = req.MaxPeople
 	activity.Moods = req.Moods
-	if err := db.DB.Save(&activity).Error; err != nil {
+	err = h.activities.Update(c.Request.Context(), activity)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
@@ -125,15 +191,63 @@ func UpdateActivity(c *gin.Context) {
 }
 
 // Удалить активность (только для модератора или админа)
-func DeleteActivity(c *gin.Context) {
+func (h *ActivityHandler) Delete(c *gin.Context) {
 	if !utils.IsModeratorOrAdmin(c) {
 		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 		return
 	}
-	id := c.Param("id")
-	if err := db.DB.Delete(&models.Activity{}, id).Error; err != nil {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err := h.activities.Delete(c.Request.Context(), id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// queryInt возвращает целый query-параметр или nil, если его нет или он не число
+func queryInt(c *gin.Context, name string) *int {
+	v, err := strconv.Atoi(c.Query(name))
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// paramID разбирает положительный id из параметра пути
+func paramID(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// maxTime — верхняя граница времени: max_time, а для совместимости и time
+func maxTime(c *gin.Context) *int {
+	if v := queryInt(c, "max_time"); v != nil {
+		return v
+	}
+	return queryInt(c, "time")
+}
+
+// normalizeActivity проверяет погоду и размер компании активности и приводит их к каноничному виду
+func normalizeActivity(a *models.Activity) bool {
+	conditions, err := weather.Normalize(a.Weather)
+	if err != nil {
+		return false
+	}
+	a.Weather = conditions
+	return normalizeGroup(a)
+}
+
+// normalizeGroup проверяет диапазон размера компании; не заданный min_people — 1
+func normalizeGroup(a *models.Activity) bool {
+	if a.MinPeople == 0 {
+		a.MinPeople = 1
+	}
+	return a.MinPeople >= 1 && (a.MaxPeople == nil || *a.MaxPeople >= a.MinPeople)
+}
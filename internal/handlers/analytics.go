@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/utils"
+)
+
+type AnalyticsHandler struct {
+	searchEvents repository.SearchEventRepository
+}
+
+func NewAnalyticsHandler(searchEvents repository.SearchEventRepository) *AnalyticsHandler {
+	return &AnalyticsHandler{searchEvents: searchEvents}
+}
+
+// GET /api/analytics/search?days=30&limit=10 — что ищут пользователи (только moderator/admin)
+func (h *AnalyticsHandler) Search(c *gin.Context) {
+	if !utils.IsModeratorOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	days, err := strconv.Atoi(c.DefaultQuery("days", "30"))
+	if err != nil || days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days param"})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > maxPerPage {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit param"})
+		return
+	}
+	from := time.Now().AddDate(0, 0, -days)
+	stats, err := h.searchEvents.Stats(c.Request.Context(), from, limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
@@ -1,17 +1,24 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/zenrush/backend/internal/db"
 	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/utils"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
 type RegisterRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=64"`
 	Password string `json:"password" binding:"required,min=6,max=64"`
@@ -22,22 +29,31 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // Время жизни access-токена в секундах
+}
+
+type AuthHandler struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+}
+
+func NewAuthHandler(users repository.UserRepository, sessions repository.SessionRepository) *AuthHandler {
+	return &AuthHandler{users: users, sessions: sessions}
 }
 
-func Register(c *gin.Context) {
+func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
-	var exists int64
-	db.DB.Model(&models.User{}).Where("username = ?", req.Username).Count(&exists)
-	if exists > 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "user already exists"})
-		return
-	}
 	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "server error"})
@@ -48,21 +64,26 @@ func Register(c *gin.Context) {
 		PasswordHash: string(hash),
 		Role:         "user",
 	}
-	if err := db.DB.Create(&user).Error; err != nil {
+	err = h.users.Create(c.Request.Context(), &user)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "user already exists"})
+		return
+	}
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
 	c.Status(http.StatusCreated)
 }
 
-func Login(c *gin.Context) {
+func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
-	var user models.User
-	if err := db.DB.Where("username = ?", req.Username).First(&user).Error; err != nil {
+	user, err := h.users.GetByUsername(c.Request.Context(), req.Username)
+	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
@@ -70,20 +91,106 @@ func Login(c *gin.Context) {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
 		return
 	}
-	token, err := generateJWT(user)
+	sessionID, err := utils.RandomID(16)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "token error"})
+		return
+	}
+	refresh, token, err := newRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "token error"})
+		return
+	}
+	token.SessionID = sessionID
+	token.UserID = user.ID
+	session := models.Session{ID: sessionID, UserID: user.ID}
+	if err := h.sessions.Create(c.Request.Context(), &session, token); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	access, err := generateJWT(*user, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "token error"})
+		return
+	}
+	c.JSON(http.StatusOK, newLoginResponse(access, refresh))
+}
+
+// POST /api/auth/refresh
+// Обменивает refresh-токен на новую пару токенов. Каждый refresh-токен
+// одноразовый: повторное предъявление уже использованного токена означает,
+// что он утёк, поэтому отзывается вся сессия.
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	refresh, token, err := newRefreshToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "token error"})
+		return
+	}
+	err = h.sessions.Rotate(c.Request.Context(), utils.HashToken(req.RefreshToken), token, time.Now())
+	if errors.Is(err, repository.ErrTokenInvalid) || errors.Is(err, repository.ErrTokenReused) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid refresh token"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	user, err := h.users.GetByID(c.Request.Context(), token.UserID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	access, err := generateJWT(*user, token.SessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "token error"})
 		return
 	}
-	c.JSON(http.StatusOK, LoginResponse{Token: token})
+	c.JSON(http.StatusOK, newLoginResponse(access, refresh))
+}
+
+// POST /api/auth/logout
+// Отзывает текущую сессию: её refresh-токены и выданные в ней access-токены перестают работать
+func (h *AuthHandler) Logout(c *gin.Context) {
+	if err := h.sessions.Revoke(c.Request.Context(), c.GetString("session_id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// newRefreshToken генерирует refresh-токен; в модель попадает только его хеш
+func newRefreshToken() (string, *models.RefreshToken, error) {
+	refresh, err := utils.RandomToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+	token := &models.RefreshToken{
+		TokenHash: utils.HashToken(refresh),
+		ExpiresAt: time.Now().Add(refreshTokenTTL),
+	}
+	return refresh, token, nil
+}
+
+func newLoginResponse(access, refresh string) LoginResponse {
+	return LoginResponse{
+		Token:        access,
+		RefreshToken: refresh,
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}
 }
 
-func generateJWT(user models.User) (string, error) {
+func generateJWT(user models.User, sessionID string) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id":  user.ID,
 		"username": user.Username,
 		"role":     user.Role,
-		"exp":      time.Now().Add(24 * time.Hour).Unix(),
+		"sid":      sessionID,
+		"exp":      time.Now().Add(accessTokenTTL).Unix(),
 	}
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return t.SignedString([]byte(os.Getenv("JWT_SECRET")))
@@ -0,0 +1,309 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type CollectionHandler struct {
+	collections repository.CollectionRepository
+	activities  repository.ActivityRepository
+}
+
+func NewCollectionHandler(collections repository.CollectionRepository, activities repository.ActivityRepository) *CollectionHandler {
+	return &CollectionHandler{collections: collections, activities: activities}
+}
+
+type CollectionRequest struct {
+	Name        string `json:"name" binding:"required,max=128"`
+	Description string `json:"description" binding:"max=2000"`
+	Cover       string `json:"cover" binding:"omitempty,url,max=512"` // URL обложки
+}
+
+type CollectionItemRequest struct {
+	ActivityID uint   `json:"activity_id" binding:"required"`
+	Note       string `json:"note" binding:"max=1000"`
+}
+
+type CollectionItemUpdateRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+type CollectionOrderRequest struct {
+	ActivityIDs []uint `json:"activity_ids" binding:"required"`
+}
+
+// GET /api/collections
+// Коллекции пользователя с числом активностей; первой идёт избранное
+func (h *CollectionHandler) List(c *gin.Context) {
+	collections, err := h.collections.List(c.Request.Context(), c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, collections)
+}
+
+// POST /api/collections
+func (h *CollectionHandler) Create(c *gin.Context) {
+	var req CollectionRequest
+	if !bindCollection(c, &req) {
+		return
+	}
+	collection := models.Collection{
+		UserID:      c.GetUint("user_id"),
+		Name:        req.Name,
+		Description: req.Description,
+		Cover:       req.Cover,
+		Items:       []models.CollectionItem{},
+	}
+	if err := h.collections.Create(c.Request.Context(), &collection); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusCreated, collection)
+}
+
+// GET /api/collections/:id
+// Коллекция с активностями в заданном пользователем порядке
+func (h *CollectionHandler) Get(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// PUT /api/collections/:id
+// Меняет название, описание и обложку; у избранного тоже
+func (h *CollectionHandler) Update(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	var req CollectionRequest
+	if !bindCollection(c, &req) {
+		return
+	}
+	collection.Name, collection.Description, collection.Cover = req.Name, req.Description, req.Cover
+	err := h.collections.Update(c.Request.Context(), collection)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// DELETE /api/collections/:id
+// Избранное удалить нельзя, только очистить
+func (h *CollectionHandler) Delete(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	if collection.IsDefault {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "default collection cannot be deleted"})
+		return
+	}
+	err := h.collections.Delete(c.Request.Context(), collection.UserID, collection.ID)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// POST /api/collections/:id/items
+// Добавляет активность в конец коллекции. Повторное добавление не ошибка: 200 вместо 201
+func (h *CollectionHandler) AddItem(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	var req CollectionItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	for _, item := range collection.Items {
+		if item.ActivityID == req.ActivityID {
+			c.JSON(http.StatusOK, item)
+			return
+		}
+	}
+	ctx := c.Request.Context()
+	activity, err := h.activities.Get(ctx, req.ActivityID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "activity not found"})
+		return
+	}
+	item := models.CollectionItem{CollectionID: collection.ID, ActivityID: activity.ID, Note: req.Note, Activity: activity}
+	err = h.collections.AddItem(ctx, &item)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		// Добавили параллельным запросом
+		c.JSON(http.StatusOK, item)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusCreated, item)
+}
+
+// PUT /api/collections/:id/items/:activity_id
+// Меняет заметку к активности в коллекции
+func (h *CollectionHandler) UpdateItem(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	item, ok := collectionItem(c, collection)
+	if !ok {
+		return
+	}
+	var req CollectionItemUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	item.Note = req.Note
+	err := h.collections.UpdateItem(c.Request.Context(), &item)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// DELETE /api/collections/:id/items/:activity_id
+func (h *CollectionHandler) RemoveItem(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	activityID, ok := paramID(c, "activity_id")
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity_id"})
+		return
+	}
+	if err := h.collections.RemoveItem(c.Request.Context(), collection.ID, activityID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// PUT /api/collections/:id/order
+// Новый порядок активностей: в activity_ids должны быть все активности коллекции ровно по разу
+func (h *CollectionHandler) Reorder(c *gin.Context) {
+	collection, ok := h.collection(c)
+	if !ok {
+		return
+	}
+	var req CollectionOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	if !samePermutation(collection.Items, req.ActivityIDs) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "activity_ids must list every activity of the collection once"})
+		return
+	}
+	ctx := c.Request.Context()
+	if err := h.collections.Reorder(ctx, collection.ID, req.ActivityIDs); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	collection, err := h.collections.Get(ctx, collection.UserID, collection.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, collection)
+}
+
+// collection загружает коллекцию текущего пользователя из :id; при ошибке сам отвечает 404 или 500
+func (h *CollectionHandler) collection(c *gin.Context) (*models.Collection, bool) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return nil, false
+	}
+	collection, err := h.collections.Get(c.Request.Context(), c.GetUint("user_id"), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return nil, false
+	}
+	return collection, true
+}
+
+// collectionItem находит в коллекции активность из :activity_id; если её там нет, отвечает 404
+func collectionItem(c *gin.Context, collection *models.Collection) (models.CollectionItem, bool) {
+	activityID, ok := paramID(c, "activity_id")
+	if ok {
+		for _, item := range collection.Items {
+			if item.ActivityID == activityID {
+				return item, true
+			}
+		}
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+	return models.CollectionItem{}, false
+}
+
+// bindCollection разбирает тело запроса коллекции; пустое после обрезки пробелов название — 400
+func bindCollection(c *gin.Context, req *CollectionRequest) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return false
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	if req.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return false
+	}
+	return true
+}
+
+// samePermutation — перечислены ли в ids все активности коллекции ровно по разу
+func samePermutation(items []models.CollectionItem, ids []uint) bool {
+	if len(items) != len(ids) {
+		return false
+	}
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	if len(seen) != len(ids) {
+		return false
+	}
+	for _, item := range items {
+		if !seen[item.ActivityID] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/insights"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/moods"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type CompletionRequest struct {
+	MoodBefore      string `json:"mood_before" binding:"required"`
+	MoodAfter       string `json:"mood_after" binding:"required"`
+	IntensityBefore *int   `json:"intensity_before"` // 1–5, по умолчанию 3
+	IntensityAfter  *int   `json:"intensity_after"`  // 1–5, по умолчанию 3
+	Note            string `json:"note"`
+	CompletedAt     string `json:"completed_at"` // RFC 3339, опционально; по умолчанию сейчас
+}
+
+type CompletionHandler struct {
+	completions repository.CompletionRepository
+	activities  repository.ActivityRepository
+	moods       repository.MoodRepository
+}
+
+func NewCompletionHandler(
+	completions repository.CompletionRepository,
+	activities repository.ActivityRepository,
+	moods repository.MoodRepository,
+) *CompletionHandler {
+	return &CompletionHandler{completions: completions, activities: activities, moods: moods}
+}
+
+// POST /api/activities/:id/complete
+// Отметить активность выполненной с настроением до и после
+func (h *CompletionHandler) Complete(c *gin.Context) {
+	ctx := c.Request.Context()
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	var req CompletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	activity, err := h.activities.Get(ctx, id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	list, err := h.moods.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	dictionary := moods.NewDictionary(list)
+	before, okBefore := dictionary.Normalize(req.MoodBefore)
+	after, okAfter := dictionary.Normalize(req.MoodAfter)
+	if !okBefore || !okAfter {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mood"})
+		return
+	}
+	intensityBefore, okBefore := moodIntensity(req.IntensityBefore)
+	intensityAfter, okAfter := moodIntensity(req.IntensityAfter)
+	if !okBefore || !okAfter {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid intensity"})
+		return
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxMoodNoteLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "note too long"})
+		return
+	}
+	completedAt := time.Now()
+	if req.CompletedAt != "" {
+		completedAt, err = time.Parse(time.RFC3339, req.CompletedAt)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid completed_at format"})
+			return
+		}
+	}
+	completion := models.ActivityCompletion{
+		UserID:          c.GetUint("user_id"),
+		ActivityID:      activity.ID,
+		MoodBefore:      before,
+		IntensityBefore: intensityBefore,
+		MoodAfter:       after,
+		IntensityAfter:  intensityAfter,
+		Note:            note,
+		CompletedAt:     completedAt,
+	}
+	if err := h.completions.Add(ctx, &completion); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	completion.Activity = activity
+	c.JSON(http.StatusCreated, completion)
+}
+
+// GET /api/users/me/insights?days=180
+// Какие активности и настроения поднимают пользователю настроение
+func (h *CompletionHandler) Insights(c *gin.Context) {
+	ctx := c.Request.Context()
+	days, err := strconv.Atoi(c.DefaultQuery("days", "180"))
+	if err != nil || days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days param"})
+		return
+	}
+	list, err := h.moods.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	completions, err := h.completions.ListSince(ctx, c.GetUint("user_id"), time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, insights.Analyze(completions, moods.NewDictionary(list).Valence()))
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/export"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type ExportHandler struct {
+	moodStats   repository.MoodStatRepository
+	history     repository.HistoryRepository
+	favorites   repository.FavoriteRepository
+	completions repository.CompletionRepository
+}
+
+func NewExportHandler(
+	moodStats repository.MoodStatRepository,
+	history repository.HistoryRepository,
+	favorites repository.FavoriteRepository,
+	completions repository.CompletionRepository,
+) *ExportHandler {
+	return &ExportHandler{moodStats: moodStats, history: history, favorites: favorites, completions: completions}
+}
+
+// exportData — наборы данных в порядке выгрузки
+var exportData = []string{"mood", "history", "favorites", "completions"}
+
+// GET /api/users/me/export?format=json|csv|zip&data=mood,history,favorites,completions
+// Выгрузка личных данных. CSV — один набор (по умолчанию mood), JSON и ZIP — все
+// перечисленные (по умолчанию все). Ответ пишется потоком по мере чтения из базы.
+func (h *ExportHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "zip" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid format param"})
+		return
+	}
+	names := exportData
+	if v := c.Query("data"); v != "" {
+		names = nil
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if !slices.Contains(exportData, name) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid data param"})
+				return
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	} else if format == "csv" {
+		names = []string{"mood"}
+	}
+	if format == "csv" && len(names) != 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "csv export takes one dataset, use format=zip"})
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	datasets := make([]export.Dataset, len(names))
+	for i, name := range names {
+		datasets[i] = h.dataset(name, userID)
+	}
+	filename := "zenrush-export-" + time.Now().Format("2006-01-02")
+	if format == "csv" {
+		filename = "zenrush-" + names[0] + "-" + time.Now().Format("2006-01-02")
+	}
+	contentType := map[string]string{
+		"json": "application/json; charset=utf-8",
+		"csv":  "text/csv; charset=utf-8",
+		"zip":  "application/zip",
+	}[format]
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="`+filename+"."+format+`"`)
+	c.Status(http.StatusOK)
+
+	ctx := c.Request.Context()
+	var err error
+	switch format {
+	case "csv":
+		err = export.WriteCSV(ctx, c.Writer, datasets[0])
+	case "json":
+		err = export.WriteJSON(ctx, c.Writer, datasets)
+	case "zip":
+		err = export.WriteZip(ctx, c.Writer, datasets)
+	}
+	// Заголовки уже отправлены, поменять статус нельзя — только обрываем ответ
+	if err != nil {
+		log.Printf("export for user %d: %v", userID, err)
+		c.Abort()
+	}
+}
+
+func (h *ExportHandler) dataset(name string, userID uint) export.Dataset {
+	switch name {
+	case "mood":
+		return export.Dataset{
+			Name:    name,
+			Columns: []string{"id", "date", "logged_at", "mood", "intensity", "note", "tags"},
+			Rows: func(ctx context.Context, emit func(...any) error) error {
+				return h.moodStats.EachEntry(ctx, userID, func(e models.MoodEntry) error {
+					return emit(e.ID, e.Date.Format("2006-01-02"), e.LoggedAt, e.Mood, e.Intensity, e.Note, nonNil(e.Tags))
+				})
+			},
+		}
+	case "history":
+		return export.Dataset{
+			Name:    name,
+			Columns: []string{"id", "viewed_at", "activity_id", "activity"},
+			Rows: func(ctx context.Context, emit func(...any) error) error {
+				return h.history.Each(ctx, userID, func(v models.History) error {
+					return emit(v.ID, v.ViewedAt, v.ActivityID, activityName(v.Activity))
+				})
+			},
+		}
+	case "favorites":
+		return export.Dataset{
+			Name:    name,
+			Columns: []string{"activity_id", "favorited_at", "activity", "budget", "time", "moods"},
+			Rows: func(ctx context.Context, emit func(...any) error) error {
+				return h.favorites.Each(ctx, userID, func(a models.Activity) error {
+					return emit(a.ID, a.FavoritedAt, a.Name, a.Budget, a.Time, nonNil(a.Moods))
+				})
+			},
+		}
+	default:
+		return export.Dataset{
+			Name: name,
+			Columns: []string{"id", "completed_at", "activity_id", "activity",
+				"mood_before", "intensity_before", "mood_after", "intensity_after", "note"},
+			Rows: func(ctx context.Context, emit func(...any) error) error {
+				return h.completions.Each(ctx, userID, func(v models.ActivityCompletion) error {
+					return emit(v.ID, v.CompletedAt, v.ActivityID, activityName(v.Activity),
+						v.MoodBefore, v.IntensityBefore, v.MoodAfter, v.IntensityAfter, v.Note)
+				})
+			},
+		}
+	}
+}
+
+// activityName — название активности или пусто, если она удалена
+func activityName(a *models.Activity) string {
+	if a == nil {
+		return ""
+	}
+	return a.Name
+}
+
+// nonNil — список без nil, чтобы в JSON был [], а не null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
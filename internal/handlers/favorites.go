@@ -1,61 +1,112 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/zenrush/backend/internal/db"
-	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
 )
 
-// Получить все избранные активности пользователя
-func ListFavorites(c *gin.Context) {
+type FavoriteHandler struct {
+	favorites  repository.FavoriteRepository
+	activities repository.ActivityRepository
+}
+
+func NewFavoriteHandler(favorites repository.FavoriteRepository, activities repository.ActivityRepository) *FavoriteHandler {
+	return &FavoriteHandler{favorites: favorites, activities: activities}
+}
+
+// Получить все избранные активности пользователя, по умолчанию сначала недавно добавленные
+func (h *FavoriteHandler) List(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	var favorites []models.Favorite
-	if err := db.DB.Where("user_id = ?", userID).Find(&favorites).Error; err != nil {
+	order, ok := parseActivityOrder(c, repository.SortByFavoritedAt)
+	if !ok {
+		return
+	}
+	page, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+	activities, total, err := h.favorites.List(c.Request.Context(), userID, order, page)
+	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
-	var activityIDs []uint
-	for _, f := range favorites {
-		activityIDs = append(activityIDs, f.ActivityID)
+	setPageHeaders(c, page, total)
+	c.JSON(http.StatusOK, activities)
+}
+
+// GET /api/favorites/check?ids=1,2,3
+// Какие из активностей в избранном: { "1": true, "2": false, "3": true }
+func (h *FavoriteHandler) Check(c *gin.Context) {
+	var ids []uint
+	for _, v := range c.QueryArray("ids") {
+		for _, s := range strings.Split(v, ",") {
+			id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+			if err != nil || id == 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ids param"})
+				return
+			}
+			ids = append(ids, uint(id))
+		}
 	}
-	var activities []models.Activity
-	if len(activityIDs) > 0 {
-		db.DB.Where("id IN ?", activityIDs).Find(&activities)
+	if len(ids) == 0 || len(ids) > maxPerPage {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ids param"})
+		return
 	}
-	if activities == nil {
-		activities = []models.Activity{}
+	favorited, err := h.favorites.Favorited(c.Request.Context(), c.GetUint("user_id"), ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
 	}
-	c.JSON(http.StatusOK, activities)
+	result := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		result[id] = false
+	}
+	for _, id := range favorited {
+		result[id] = true
+	}
+	c.JSON(http.StatusOK, result)
 }
 
-// Добавить активность в избранное
-func AddFavorite(c *gin.Context) {
+// Добавить активность в избранное. Повторное добавление — не ошибка: 200 вместо 201
+func (h *FavoriteHandler) Add(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	activityID, err := strconv.Atoi(c.Param("activity_id"))
-	if err != nil {
+	activityID, ok := paramID(c, "activity_id")
+	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity_id"})
 		return
 	}
-	fav := models.Favorite{UserID: userID, ActivityID: uint(activityID)}
-	if err := db.DB.Create(&fav).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error or already exists"})
+	ctx := c.Request.Context()
+	// Удалённые активности Get тоже не находит
+	if _, err := h.activities.Get(ctx, activityID); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	err := h.favorites.Add(ctx, userID, activityID)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		c.Status(http.StatusOK)
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
 	c.Status(http.StatusCreated)
 }
 
 // Удалить активность из избранного
-func RemoveFavorite(c *gin.Context) {
+func (h *FavoriteHandler) Remove(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	activityID, err := strconv.Atoi(c.Param("activity_id"))
-	if err != nil {
+	activityID, ok := paramID(c, "activity_id")
+	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity_id"})
 		return
 	}
-	if err := db.DB.Delete(&models.Favorite{}, "user_id = ? AND activity_id = ?", userID, activityID).Error; err != nil {
+	if err := h.favorites.Remove(c.Request.Context(), userID, activityID); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/moods"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+// weatherAuto — значение weather=, вместо которого подставляется погода в городе пользователя
+const weatherAuto = "auto"
+
+// filterSource разбирает параметры подбора активностей: погоду (в том числе
+// текущую в городе пользователя) и настроения по словарю
+type filterSource struct {
+	users    repository.UserRepository
+	forecast weather.Provider // nil — погода не определяется
+	moods    repository.MoodRepository
+}
+
+// conditions разбирает значения weather; auto заменяется текущей погодой.
+// При ошибке сам отвечает 400.
+func (f filterSource) conditions(c *gin.Context, values []string) ([]string, bool) {
+	if len(values) == 1 && strings.EqualFold(strings.TrimSpace(values[0]), weatherAuto) {
+		return f.current(c), true
+	}
+	conditions, err := weather.ParseQuery(values)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid weather param"})
+		return nil, false
+	}
+	return conditions, true
+}
+
+// current возвращает погоду сейчас в городе пользователя и пишет её в заголовок X-Weather.
+// Если город не указан или погода недоступна, возвращает nil — фильтр по погоде не применяется.
+func (f filterSource) current(c *gin.Context) []string {
+	if f.forecast == nil {
+		return nil
+	}
+	ctx := c.Request.Context()
+	user, err := f.users.GetByID(ctx, c.GetUint("user_id"))
+	if err != nil || user.City == "" {
+		return nil
+	}
+	condition, err := f.forecast.Current(ctx, user.City)
+	if err != nil {
+		log.Printf("weather for %q: %v", user.City, err)
+		return nil
+	}
+	c.Header("X-Weather", condition)
+	return []string{condition}
+}
+
+func (f filterSource) dictionary(ctx context.Context) (moods.Dictionary, error) {
+	list, err := f.moods.List(ctx)
+	if err != nil {
+		return moods.Dictionary{}, err
+	}
+	return moods.NewDictionary(list), nil
+}
+
+// moodSlugs приводит настроения (slug'и или подписи) к slug'ам словаря; значения можно
+// перечислять через запятую. При ошибке сам отвечает 400 или 500.
+func (f filterSource) moodSlugs(c *gin.Context, values []string) ([]string, bool) {
+	var split []string
+	for _, v := range values {
+		split = append(split, strings.Split(v, ",")...)
+	}
+	dict, err := f.dictionary(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return nil, false
+	}
+	slugs, err := dict.NormalizeAll(split)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mood"})
+		return nil, false
+	}
+	return slugs, true
+}
+
+// activityFilter разбирает фильтры списка активностей из query-параметров;
+// при ошибке сам отвечает 400
+func (f filterSource) activityFilter(c *gin.Context) (repository.ActivityFilter, bool) {
+	conditions, ok := f.conditions(c, c.QueryArray("weather"))
+	if !ok {
+		return repository.ActivityFilter{}, false
+	}
+	slugs, ok := f.moodSlugs(c, c.QueryArray("mood"))
+	if !ok {
+		return repository.ActivityFilter{}, false
+	}
+	match := repository.MoodMatch(c.DefaultQuery("mood_match", string(repository.MoodMatchAny)))
+	if match != repository.MoodMatchAny && match != repository.MoodMatchAll {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mood_match param"})
+		return repository.ActivityFilter{}, false
+	}
+	return repository.ActivityFilter{
+		Query:       strings.TrimSpace(c.Query("q")),
+		MinBudget:   queryInt(c, "min_budget"),
+		MaxBudget:   queryInt(c, "max_budget"),
+		MinTime:     queryInt(c, "min_time"),
+		MaxTime:     maxTime(c),
+		Moods:       slugs,
+		MoodMatch:   match,
+		Weather:     conditions,
+		PeopleCount: queryInt(c, "people_count"),
+	}, true
+}
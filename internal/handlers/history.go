@@ -1,45 +1,105 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/zenrush/backend/internal/db"
+	"github.com/zenrush/backend/internal/localtime"
 	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
 )
 
-// Получить последние 10 просмотренных активностей пользователя
-func ListHistory(c *gin.Context) {
+type HistoryHandler struct {
+	timezoneSource
+	history repository.HistoryRepository
+}
+
+func NewHistoryHandler(history repository.HistoryRepository, users repository.UserRepository, fallback *time.Location) *HistoryHandler {
+	return &HistoryHandler{timezoneSource: timezoneSource{users: users, fallback: fallback}, history: history}
+}
+
+// Получить просмотры пользователя с активностями, от новых к старым (по умолчанию последние 10).
+// date=YYYY-MM-DD — только за этот день по часовому поясу пользователя,
+// distinct=true — только последний просмотр каждой активности.
+func (h *HistoryHandler) List(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	var history []models.History
-	if err := db.DB.Where("user_id = ?", userID).Order("viewed_at desc").Limit(10).Find(&history).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+	page, ok := parsePage(c, 10)
+	if !ok {
 		return
 	}
-	var activityIDs []uint
-	for _, h := range history {
-		activityIDs = append(activityIDs, h.ActivityID)
+	var filter repository.HistoryFilter
+	if v := c.Query("distinct"); v != "" {
+		distinct, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid distinct param"})
+			return
+		}
+		filter.Distinct = distinct
 	}
-	var activities []models.Activity
-	if len(activityIDs) > 0 {
-		db.DB.Where("id IN ?", activityIDs).Find(&activities)
+	if v := c.Query("date"); v != "" {
+		day, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format"})
+			return
+		}
+		loc, ok := h.location(c)
+		if !ok {
+			return
+		}
+		filter.From, filter.To = localtime.Start(day, loc), localtime.Start(day.AddDate(0, 0, 1), loc)
 	}
-	c.JSON(http.StatusOK, activities)
+	entries, total, err := h.history.List(c.Request.Context(), userID, filter, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	setPageHeaders(c, page, total)
+	c.JSON(http.StatusOK, entries)
 }
 
 // Добавить просмотр активности в историю
-func AddHistory(c *gin.Context) {
+func (h *HistoryHandler) Add(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	activityID, err := strconv.Atoi(c.Param("activity_id"))
-	if err != nil {
+	activityID, ok := paramID(c, "activity_id")
+	if !ok {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid activity_id"})
 		return
 	}
-	h := models.History{UserID: userID, ActivityID: uint(activityID)}
-	if err := db.DB.Create(&h).Error; err != nil {
+	entry := models.History{UserID: userID, ActivityID: activityID}
+	if err := h.history.Add(c.Request.Context(), &entry); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
 	c.Status(http.StatusCreated)
 }
+
+// Удалить один просмотр из истории
+func (h *HistoryHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	err := h.history.Delete(c.Request.Context(), c.GetUint("user_id"), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Очистить всю историю пользователя
+func (h *HistoryHandler) Clear(c *gin.Context) {
+	if err := h.history.Clear(c.Request.Context(), c.GetUint("user_id")); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/itinerary"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/recommend"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+const defaultItineraryTitle = "План на день"
+
+type ItineraryHandler struct {
+	profileSource
+	filterSource
+	itineraries repository.ItineraryRepository
+}
+
+func NewItineraryHandler(
+	itineraries repository.ItineraryRepository,
+	activities repository.ActivityRepository,
+	favorites repository.FavoriteRepository,
+	history repository.HistoryRepository,
+	moodStats repository.MoodStatRepository,
+	searches repository.SearchEventRepository,
+	completions repository.CompletionRepository,
+	users repository.UserRepository,
+	forecast weather.Provider,
+	moods repository.MoodRepository,
+) *ItineraryHandler {
+	return &ItineraryHandler{
+		profileSource: profileSource{activities: activities, favorites: favorites, history: history, moodStats: moodStats, searches: searches, completions: completions},
+		filterSource:  filterSource{users: users, forecast: forecast, moods: moods},
+		itineraries:   itineraries,
+	}
+}
+
+type ItineraryRequest struct {
+	Title       string   `json:"title" binding:"max=128"`
+	Budget      *int     `json:"budget" binding:"required,min=0"`
+	Hours       int      `json:"hours" binding:"required,min=1,max=24"`
+	Mood        string   `json:"mood"` // slug или подпись из словаря настроений
+	PeopleCount *int     `json:"people_count" binding:"omitempty,min=1"`
+	Weather     []string `json:"weather"` // ["auto"] — погода сейчас в городе пользователя
+	MaxItems    int      `json:"max_items" binding:"omitempty,min=1,max=8"`
+	// DryRun — только составить план, не сохраняя его
+	DryRun bool `json:"dry_run"`
+}
+
+// POST /api/itineraries
+// Составляет план из нескольких активностей, который укладывается в бюджет и время
+// и лучше всего подходит пользователю, и сохраняет его (если не dry_run)
+func (h *ItineraryHandler) Create(c *gin.Context) {
+	var req ItineraryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	conditions, ok := h.conditions(c, req.Weather)
+	if !ok {
+		return
+	}
+	slugs, ok := h.moodSlugs(c, []string{req.Mood})
+	if !ok {
+		return
+	}
+	if len(slugs) > 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mood"})
+		return
+	}
+	mood := strings.Join(slugs, "")
+	dict, err := h.dictionary(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	now := time.Now()
+	candidates, err := h.candidates(c, repository.ActivityFilter{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	profile, err := h.profile(c, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	scored := recommend.Recommend(candidates, profile, recommend.Context{
+		Budget:      req.Budget,
+		Hours:       &req.Hours,
+		Weather:     conditions,
+		PeopleCount: req.PeopleCount,
+		Moods:       slugs,
+	}, recommend.Options{Now: now, MoodLabels: dict.Labels(), MoodValence: dict.Valence()})
+	pool := make([]itinerary.Candidate, len(scored))
+	for i, r := range scored {
+		pool[i] = itinerary.Candidate{Activity: r.Activity, Score: r.Score}
+	}
+	plan := itinerary.Build(pool, itinerary.Request{Budget: *req.Budget, Hours: req.Hours, MaxItems: req.MaxItems})
+	if len(plan.Items) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no matching activities"})
+		return
+	}
+
+	it := models.Itinerary{
+		UserID:      c.GetUint("user_id"),
+		Title:       req.Title,
+		Budget:      *req.Budget,
+		Hours:       req.Hours,
+		Mood:        mood,
+		PeopleCount: req.PeopleCount,
+		TotalBudget: plan.TotalBudget,
+		TotalTime:   plan.TotalTime,
+		Score:       plan.Score,
+	}
+	if it.Title == "" {
+		it.Title = defaultItineraryTitle
+	}
+	for i, item := range plan.Items {
+		it.Items = append(it.Items, models.ItineraryItem{
+			ActivityID:  item.Activity.ID,
+			Position:    i + 1,
+			StartOffset: item.StartOffset,
+			Score:       item.Score,
+			Activity:    &item.Activity,
+		})
+	}
+	if req.DryRun {
+		it.CreatedAt = now
+		c.JSON(http.StatusOK, it)
+		return
+	}
+	if err := h.itineraries.Create(c.Request.Context(), &it); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusCreated, it)
+}
+
+// GET /api/itineraries
+func (h *ItineraryHandler) List(c *gin.Context) {
+	page, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+	itineraries, total, err := h.itineraries.List(c.Request.Context(), c.GetUint("user_id"), page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	setPageHeaders(c, page, total)
+	c.JSON(http.StatusOK, itineraries)
+}
+
+// GET /api/itineraries/:id
+func (h *ItineraryHandler) Get(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	it, err := h.itineraries.Get(c.Request.Context(), c.GetUint("user_id"), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, it)
+}
+
+// DELETE /api/itineraries/:id
+func (h *ItineraryHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	err := h.itineraries.Delete(c.Request.Context(), c.GetUint("user_id"), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
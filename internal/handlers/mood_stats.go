@@ -1,71 +1,357 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
-	"github.com/zenrush/backend/internal/db"
+	"github.com/lib/pq"
+	"github.com/zenrush/backend/internal/localtime"
 	"github.com/zenrush/backend/internal/models"
-	"gorm.io/gorm/clause"
+	"github.com/zenrush/backend/internal/moods"
+	"github.com/zenrush/backend/internal/moodstats"
+	"github.com/zenrush/backend/internal/repository"
 )
 
+// MoodStatUpdateRequest — поля записи, которые можно изменить
+type MoodStatUpdateRequest struct {
+	Mood      string   `json:"mood" binding:"required"`
+	Intensity *int     `json:"intensity"` // 1–5, по умолчанию 3
+	Note      string   `json:"note"`
+	Tags      []string `json:"tags"`
+}
+
 type MoodStatRequest struct {
-	Mood string `json:"mood" binding:"required"`
-	Date string `json:"date"` // YYYY-MM-DD, опционально
+	MoodStatUpdateRequest
+	Date     string `json:"date"`      // YYYY-MM-DD, опционально
+	LoggedAt string `json:"logged_at"` // RFC 3339, опционально; по умолчанию сейчас
+}
+
+const (
+	defaultMoodIntensity = 3
+	maxMoodNoteLength    = 1000
+	maxMoodTags          = 10
+	maxMoodTagLength     = 32
+	// maxMoodRangeDays — сколько дней можно запросить через from/to
+	maxMoodRangeDays = 366
+)
+
+type MoodStatHandler struct {
+	timezoneSource
+	moodStats repository.MoodStatRepository
+	moods     repository.MoodRepository
+}
+
+func NewMoodStatHandler(
+	moodStats repository.MoodStatRepository,
+	moods repository.MoodRepository,
+	users repository.UserRepository,
+	fallback *time.Location,
+) *MoodStatHandler {
+	return &MoodStatHandler{
+		timezoneSource: timezoneSource{users: users, fallback: fallback},
+		moodStats:      moodStats,
+		moods:          moods,
+	}
 }
 
 // POST /api/mood-stats
-func SaveOrUpdateMoodStat(c *gin.Context) {
-	userID := c.GetUint("user_id")
+// Добавляет запись в дневник; настроением дня считается последняя запись
+func (h *MoodStatHandler) SaveOrUpdate(c *gin.Context) {
 	var req MoodStatRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
 		return
 	}
-	var date time.Time
-	var err error
-	if req.Date != "" {
-		date, err = time.Parse("2006-01-02", req.Date)
+	loc, ok := h.location(c)
+	if !ok {
+		return
+	}
+	entry := models.MoodEntry{UserID: c.GetUint("user_id"), LoggedAt: time.Now()}
+	if req.LoggedAt != "" {
+		loggedAt, err := time.Parse(time.RFC3339, req.LoggedAt)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format"})
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid logged_at format"})
 			return
 		}
-	} else {
-		date = time.Now().Truncate(24 * time.Hour)
-	}
-	moodStat := models.MoodStat{
-		UserID: userID,
-		Date:   date,
-		Mood:   req.Mood,
-	}
-	err = db.DB.Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
-		DoUpdates: clause.AssignmentColumns([]string{"mood"}),
-	}).Create(&moodStat).Error
+		entry.LoggedAt = loggedAt
+	}
+	// День записи — по часовому поясу пользователя, а не по UTC
+	entry.Date = localtime.Day(entry.LoggedAt, loc)
+	if req.Date != "" {
+		if entry.Date, ok = parseMoodDay(c, req.Date, loc); !ok {
+			return
+		}
+	}
+	if !h.fill(c, req.MoodStatUpdateRequest, &entry) {
+		return
+	}
+	if err := h.moodStats.Add(c.Request.Context(), &entry); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusCreated, entry)
+}
+
+// PUT /api/mood-stats/:date
+// Исправляет последнюю запись дня — ту, что считается настроением дня
+func (h *MoodStatHandler) Update(c *gin.Context) {
+	loc, ok := h.location(c)
+	if !ok {
+		return
+	}
+	date, ok := parseMoodDay(c, c.Param("date"), loc)
+	if !ok {
+		return
+	}
+	var req MoodStatUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	ctx := c.Request.Context()
+	entry, err := h.moodStats.LatestOn(ctx, c.GetUint("user_id"), date)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	if !h.fill(c, req, entry) {
+		return
+	}
+	err = h.moodStats.Update(ctx, entry)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+// DELETE /api/mood-stats/:date — удалить все записи за день
+func (h *MoodStatHandler) Delete(c *gin.Context) {
+	loc, ok := h.location(c)
+	if !ok {
+		return
+	}
+	date, ok := parseMoodDay(c, c.Param("date"), loc)
+	if !ok {
+		return
+	}
+	err := h.moodStats.DeleteDay(c.Request.Context(), c.GetUint("user_id"), date)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
-	c.Status(http.StatusCreated)
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/users/me/mood-stats?days=N|from=&to=&view=daily|entries|calendar
+func (h *MoodStatHandler) List(c *gin.Context) {
+	userID := c.GetUint("user_id")
+	loc, ok := h.location(c)
+	if !ok {
+		return
+	}
+	from, to, ok := moodRange(c, localtime.Day(time.Now(), loc))
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	switch c.DefaultQuery("view", "daily") {
+	case "daily":
+		stats, err := h.moodStats.ListDays(ctx, userID, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+			return
+		}
+		c.JSON(http.StatusOK, stats)
+	case "entries":
+		entries, err := h.moodStats.ListEntries(ctx, userID, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+			return
+		}
+		c.JSON(http.StatusOK, entries)
+	case "calendar":
+		stats, err := h.moodStats.ListDays(ctx, userID, from, to)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+			return
+		}
+		c.JSON(http.StatusOK, moodstats.Calendar(stats, from, to))
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid view param"})
+	}
 }
 
-// GET /api/users/me/mood-stats?days=N
-func GetMoodStats(c *gin.Context) {
+// moodRange — дни from..to из запроса. from и to (YYYY-MM-DD) важнее days:
+// если задана только одна граница, вторая отстоит от неё на days дней, а to по умолчанию — сегодня.
+// При ошибке сам отвечает 400.
+func moodRange(c *gin.Context, today time.Time) (time.Time, time.Time, bool) {
+	days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+	if err != nil || days < 1 || days > 365 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days param"})
+		return time.Time{}, time.Time{}, false
+	}
+	from, to := today.AddDate(0, 0, -days+1), today
+	fromStr, toStr := c.Query("from"), c.Query("to")
+	if toStr != "" {
+		if to, err = time.Parse("2006-01-02", toStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format"})
+			return time.Time{}, time.Time{}, false
+		}
+		from = to.AddDate(0, 0, -days+1)
+	}
+	if fromStr != "" {
+		if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format"})
+			return time.Time{}, time.Time{}, false
+		}
+		if toStr == "" {
+			to = from.AddDate(0, 0, days-1)
+		}
+	}
+	if to.Before(from) || to.Sub(from) >= maxMoodRangeDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date range"})
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to, true
+}
+
+// parseMoodDay разбирает день записи (YYYY-MM-DD); дни в будущем по часовому поясу
+// пользователя не принимаются. При ошибке сам отвечает 400.
+func parseMoodDay(c *gin.Context, value string, loc *time.Location) (time.Time, bool) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date format"})
+		return time.Time{}, false
+	}
+	if date.After(localtime.Day(time.Now(), loc)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "date in the future"})
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// fill проверяет настроение, силу, заметку и теги и переносит их в запись.
+// При ошибке сам отвечает 400 или 500.
+func (h *MoodStatHandler) fill(c *gin.Context, req MoodStatUpdateRequest, entry *models.MoodEntry) bool {
+	dictionary, err := h.moods.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return false
+	}
+	mood, ok := moods.NewDictionary(dictionary).Normalize(req.Mood)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid mood"})
+		return false
+	}
+	intensity, ok := moodIntensity(req.Intensity)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid intensity"})
+		return false
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxMoodNoteLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "note too long"})
+		return false
+	}
+	tags, ok := normalizeTags(req.Tags)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tags"})
+		return false
+	}
+	entry.Mood, entry.Intensity, entry.Note, entry.Tags = mood, intensity, note, tags
+	return true
+}
+
+// GET /api/users/me/mood-stats/summary?days=90&period=week&trend_days=7
+func (h *MoodStatHandler) Summary(c *gin.Context) {
 	userID := c.GetUint("user_id")
-	daysStr := c.DefaultQuery("days", "7")
-	days, err := strconv.Atoi(daysStr)
+	days, err := strconv.Atoi(c.DefaultQuery("days", "90"))
 	if err != nil || days < 1 || days > 365 {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days param"})
 		return
 	}
-	fromDate := time.Now().AddDate(0, 0, -days+1).Truncate(24 * time.Hour)
-	var stats []models.MoodStat
-	err = db.DB.Where("user_id = ? AND date >= ?", userID, fromDate).Order("date asc").Find(&stats).Error
+	period := c.DefaultQuery("period", moodstats.Week)
+	if period != moodstats.Week && period != moodstats.Month {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period param"})
+		return
+	}
+	trendDays, err := strconv.Atoi(c.DefaultQuery("trend_days", "7"))
+	if err != nil || trendDays < 1 || trendDays > 90 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid trend_days param"})
+		return
+	}
+	loc, ok := h.location(c)
+	if !ok {
+		return
+	}
+	ctx := c.Request.Context()
+	dictionary, err := h.moods.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	today := localtime.Day(time.Now(), loc)
+	from := today.AddDate(0, 0, -days+1)
+	// Для тренда нужны ещё и предыдущие trend_days дней
+	entries, err := h.moodStats.ListEntries(ctx, userID, today.AddDate(0, 0, -max(days, 2*trendDays)+1), today)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	// Серии не ограничены периодом: считаются по всем дням с записями
+	logged, err := h.moodStats.LoggedDays(ctx, userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
 		return
 	}
-	c.JSON(http.StatusOK, stats)
+	summary := moodstats.Summarize(entries, logged, moods.NewDictionary(dictionary).Valence(), moodstats.Options{
+		Today:     today,
+		From:      from,
+		Period:    period,
+		TrendDays: trendDays,
+	})
+	c.JSON(http.StatusOK, summary)
+}
+
+// moodIntensity — сила настроения из запроса: по умолчанию 3, допустимо от 1 до 5
+func moodIntensity(v *int) (int, bool) {
+	if v == nil {
+		return defaultMoodIntensity, true
+	}
+	return *v, *v >= 1 && *v <= 5
+}
+
+// normalizeTags приводит теги к нижнему регистру, убирает пустые и повторы
+func normalizeTags(raw []string) (pq.StringArray, bool) {
+	tags := pq.StringArray{}
+	for _, t := range raw {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || slices.Contains(tags, t) {
+			continue
+		}
+		if utf8.RuneCountInString(t) > maxMoodTagLength {
+			return nil, false
+		}
+		tags = append(tags, t)
+	}
+	return tags, len(tags) <= maxMoodTags
 }
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/moods"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/utils"
+)
+
+type MoodHandler struct {
+	moods repository.MoodRepository
+}
+
+func NewMoodHandler(moods repository.MoodRepository) *MoodHandler {
+	return &MoodHandler{moods: moods}
+}
+
+// GET /api/moods — словарь настроений по порядку показа
+func (h *MoodHandler) List(c *gin.Context) {
+	list, err := h.moods.List(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// POST /api/moods — добавить настроение (только moderator/admin)
+func (h *MoodHandler) Create(c *gin.Context) {
+	if !utils.IsModeratorOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req models.Mood
+	if err := c.ShouldBindJSON(&req); err != nil || !h.valid(&req) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	ctx := c.Request.Context()
+	list, err := h.moods.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	// Подписи не должны совпадать с чужими, иначе настроение по подписи станет неоднозначным
+	dict := moods.NewDictionary(list)
+	for _, label := range []string{req.LabelRu, req.LabelEn} {
+		if _, ok := dict.Normalize(label); ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "mood already exists"})
+			return
+		}
+	}
+	err = h.moods.Create(ctx, &req)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{"error": "mood already exists"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusCreated, req)
+}
+
+// PUT /api/moods/:slug — изменить подписи, эмодзи, цвет или порядок (только moderator/admin)
+func (h *MoodHandler) Update(c *gin.Context) {
+	if !utils.IsModeratorOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	var req models.Mood
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	req.Slug = c.Param("slug")
+	if !h.valid(&req) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	ctx := c.Request.Context()
+	list, err := h.moods.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	dict := moods.NewDictionary(list)
+	for _, label := range []string{req.LabelRu, req.LabelEn} {
+		if slug, ok := dict.Normalize(label); ok && slug != req.Slug {
+			c.JSON(http.StatusConflict, gin.H{"error": "mood already exists"})
+			return
+		}
+	}
+	err = h.moods.Update(ctx, &req)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, req)
+}
+
+// valid подчищает пробелы и проверяет запись словаря
+func (h *MoodHandler) valid(m *models.Mood) bool {
+	m.Slug = strings.TrimSpace(m.Slug)
+	m.LabelRu = strings.TrimSpace(m.LabelRu)
+	m.LabelEn = strings.TrimSpace(m.LabelEn)
+	m.Emoji = strings.TrimSpace(m.Emoji)
+	return moods.Validate(*m)
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+const maxPerPage = 100
+
+// parsePage разбирает page (с 1) и per_page; при ошибке сам отвечает 400
+func parsePage(c *gin.Context, defaultPerPage int) (repository.Page, bool) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid page param"})
+		return repository.Page{}, false
+	}
+	perPage, err := strconv.Atoi(c.DefaultQuery("per_page", strconv.Itoa(defaultPerPage)))
+	if err != nil || perPage < 1 || perPage > maxPerPage {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid per_page param"})
+		return repository.Page{}, false
+	}
+	return repository.Page{Limit: perPage, Offset: (page - 1) * perPage}, true
+}
+
+// parseActivityOrder разбирает sort и order (asc/desc); при ошибке сам отвечает 400.
+// def — сортировка по умолчанию: relevance при полнотекстовом поиске (от лучших совпадений
+// к худшим), favorited_at в избранном (сначала недавние), иначе по id.
+func parseActivityOrder(c *gin.Context, def repository.ActivitySort) (repository.ActivityOrder, bool) {
+	sortParam, defaultOrder := c.DefaultQuery("sort", string(def)), "asc"
+	by, ok := repository.ParseActivitySort(sortParam)
+	// relevance и favorited_at есть не у каждого списка: там, где они есть, они и по умолчанию
+	contextual := by == repository.SortByRelevance || by == repository.SortByFavoritedAt
+	if contextual && by == def {
+		defaultOrder = "desc"
+	}
+	if !ok || (contextual && by != def) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort param"})
+		return repository.ActivityOrder{}, false
+	}
+	order := repository.ActivityOrder{By: by}
+	switch c.DefaultQuery("order", defaultOrder) {
+	case "asc":
+	case "desc":
+		order.Desc = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order param"})
+		return repository.ActivityOrder{}, false
+	}
+	return order, true
+}
+
+// setPageHeaders выставляет X-Total-Count и Link со ссылками на соседние страницы
+func setPageHeaders(c *gin.Context, page repository.Page, total int64) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+	current := page.Offset/page.Limit + 1
+	var links []string
+	if int64(page.Offset+page.Limit) < total {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageURL(c, current+1)))
+	}
+	if current > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, pageURL(c, current-1)))
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
+
+func pageURL(c *gin.Context, page int) string {
+	u := *c.Request.URL
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/recommend"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+const (
+	// Сколько истории и настроений учитывать при подборе
+	recommendHistoryWindow = 90 * 24 * time.Hour
+	recommendMoodWindow    = 14 * 24 * time.Hour
+	recommendSearchWindow  = 14 * 24 * time.Hour
+	// Выполненные активности помним дольше: их мало, а сигнал сильный
+	recommendCompletionWindow = 180 * 24 * time.Hour
+	// Просмотренное за это время не предлагаем повторно
+	recommendExcludeViewed = 24 * time.Hour
+	// Сколько последних случайных выборов не повторять
+	rushRecentPicks = 5
+)
+
+// profileSource собирает профиль пользователя для скоринга; общий для подборок и планов дня
+type profileSource struct {
+	activities  repository.ActivityRepository
+	favorites   repository.FavoriteRepository
+	history     repository.HistoryRepository
+	moodStats   repository.MoodStatRepository
+	searches    repository.SearchEventRepository
+	completions repository.CompletionRepository
+}
+
+type RecommendationHandler struct {
+	profileSource
+	filterSource
+	picks repository.PickRepository
+}
+
+func NewRecommendationHandler(
+	activities repository.ActivityRepository,
+	favorites repository.FavoriteRepository,
+	history repository.HistoryRepository,
+	moodStats repository.MoodStatRepository,
+	searches repository.SearchEventRepository,
+	completions repository.CompletionRepository,
+	picks repository.PickRepository,
+	users repository.UserRepository,
+	forecast weather.Provider,
+	moods repository.MoodRepository,
+) *RecommendationHandler {
+	return &RecommendationHandler{
+		profileSource: profileSource{activities: activities, favorites: favorites, history: history, moodStats: moodStats, searches: searches, completions: completions},
+		filterSource:  filterSource{users: users, forecast: forecast, moods: moods},
+		picks:         picks,
+	}
+}
+
+// GET /api/recommendations?budget=&hours=&weather=&people_count=&mood=&limit=
+// weather=auto или без weather — погода за окном в городе пользователя
+func (h *RecommendationHandler) List(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > maxPerPage {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit param"})
+		return
+	}
+	rc, ok := h.recommendContext(c)
+	if !ok {
+		return
+	}
+	now := time.Now()
+	candidates, err := h.candidates(c, repository.ActivityFilter{})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	profile, err := h.profile(c, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	dict, err := h.dictionary(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	recommendations := recommend.Recommend(candidates, profile, rc, recommend.Options{
+		Now:                 now,
+		ExcludeViewedWithin: recommendExcludeViewed,
+		Limit:               limit,
+		MoodLabels:          dict.Labels(),
+		MoodValence:         dict.Valence(),
+	})
+	c.JSON(http.StatusOK, recommendations)
+}
+
+// GET /api/activities/random?max_budget=&time=&mood=&weather=&people_count=&q=&seed=
+// «Не знаю, чем заняться»: одна случайная активность из подходящих под фильтры.
+// Вероятность выше у того, что ближе пользователю; последние выборы не повторяются.
+// seed делает выбор воспроизводимым при одинаковых данных.
+func (h *RecommendationHandler) Random(c *gin.Context) {
+	var rng *rand.Rand
+	if seedParam := c.Query("seed"); seedParam != "" {
+		seed, err := strconv.ParseUint(seedParam, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid seed param"})
+			return
+		}
+		rng = rand.New(rand.NewPCG(seed, seed))
+	} else {
+		rng = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+
+	ctx := c.Request.Context()
+	userID := c.GetUint("user_id")
+	now := time.Now()
+	filter, ok := h.activityFilter(c)
+	if !ok {
+		return
+	}
+	candidates, err := h.candidates(c, filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	profile, err := h.profile(c, now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	recent, err := h.picks.Recent(ctx, userID, rushRecentPicks)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	recentIDs := make([]uint, len(recent))
+	for i, p := range recent {
+		recentIDs[i] = p.ActivityID
+	}
+
+	// Фильтры уже применены к кандидатам, скоринг нужен только для весов
+	dict, err := h.dictionary(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	scored := recommend.Recommend(candidates, profile, recommend.Context{Moods: filter.Moods}, recommend.Options{Now: now, MoodLabels: dict.Labels(), MoodValence: dict.Valence()})
+	pick, ok := recommend.Pick(scored, recentIDs, rng)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "no matching activities"})
+		return
+	}
+	if err := h.picks.Add(ctx, &models.ActivityPick{UserID: userID, ActivityID: pick.Activity.ID}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, pick)
+}
+
+func (s profileSource) candidates(c *gin.Context, filter repository.ActivityFilter) ([]models.Activity, error) {
+	activities, _, err := s.activities.List(c.Request.Context(), filter, repository.ActivityOrder{By: repository.SortByID}, repository.Page{})
+	return activities, err
+}
+
+// profile собирает избранное, историю, настроения, недавние поиски и выполненные активности текущего пользователя
+func (s profileSource) profile(c *gin.Context, now time.Time) (recommend.Profile, error) {
+	ctx := c.Request.Context()
+	userID := c.GetUint("user_id")
+	favorites, _, err := s.favorites.List(ctx, userID, repository.ActivityOrder{By: repository.SortByID}, repository.Page{})
+	if err != nil {
+		return recommend.Profile{}, err
+	}
+	history, err := s.history.ListSince(ctx, userID, now.Add(-recommendHistoryWindow))
+	if err != nil {
+		return recommend.Profile{}, err
+	}
+	moods, err := s.moodStats.ListDays(ctx, userID, now.Add(-recommendMoodWindow), time.Time{})
+	if err != nil {
+		return recommend.Profile{}, err
+	}
+	searches, err := s.searches.ListSince(ctx, userID, now.Add(-recommendSearchWindow))
+	if err != nil {
+		return recommend.Profile{}, err
+	}
+	completions, err := s.completions.ListSince(ctx, userID, now.Add(-recommendCompletionWindow))
+	if err != nil {
+		return recommend.Profile{}, err
+	}
+	return recommend.Profile{Favorites: favorites, History: history, Moods: moods, Searches: searches, Completions: completions}, nil
+}
+
+// recommendContext разбирает условия запроса; при ошибке сам отвечает 400.
+// Если погода не указана, используется текущая погода в городе пользователя.
+func (h *RecommendationHandler) recommendContext(c *gin.Context) (recommend.Context, bool) {
+	var conditions []string
+	if values := c.QueryArray("weather"); len(values) > 0 {
+		var ok bool
+		if conditions, ok = h.conditions(c, values); !ok {
+			return recommend.Context{}, false
+		}
+	} else {
+		conditions = h.current(c)
+	}
+	slugs, ok := h.moodSlugs(c, c.QueryArray("mood"))
+	if !ok {
+		return recommend.Context{}, false
+	}
+	return recommend.Context{
+		Budget:      queryInt(c, "budget"),
+		Hours:       queryInt(c, "hours"),
+		Weather:     conditions,
+		PeopleCount: queryInt(c, "people_count"),
+		Moods:       slugs,
+	}, true
+}
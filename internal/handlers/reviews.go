@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/utils"
+)
+
+type ReviewHandler struct {
+	reviews    repository.ReviewRepository
+	activities repository.ActivityRepository
+}
+
+func NewReviewHandler(reviews repository.ReviewRepository, activities repository.ActivityRepository) *ReviewHandler {
+	return &ReviewHandler{reviews: reviews, activities: activities}
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=5"`
+	Text   string `json:"text" binding:"max=2000"`
+}
+
+// GET /api/activities/:id/reviews?page=&per_page=&include_hidden=
+// Отзывы об активности от новых к старым; скрытые видят только moderator/admin с include_hidden=true
+func (h *ReviewHandler) List(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	includeHidden := false
+	if v := c.Query("include_hidden"); v != "" {
+		var err error
+		if includeHidden, err = strconv.ParseBool(v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid include_hidden param"})
+			return
+		}
+		if includeHidden && !utils.IsModeratorOrAdmin(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+	}
+	page, ok := parsePage(c, 20)
+	if !ok {
+		return
+	}
+	reviews, total, err := h.reviews.List(c.Request.Context(), id, includeHidden, page)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	setPageHeaders(c, page, total)
+	c.JSON(http.StatusOK, reviews)
+}
+
+// GET /api/activities/:id/review
+// Свой отзыв об активности, в том числе скрытый
+func (h *ReviewHandler) Mine(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	h.respond(c, http.StatusOK, id)
+}
+
+// PUT /api/activities/:id/review
+// Оставить отзыв или исправить свой: 201, если отзыв новый, иначе 200
+func (h *ReviewHandler) Save(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	var req ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	ctx := c.Request.Context()
+	if _, err := h.activities.Get(ctx, id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	review := models.Review{
+		UserID:     c.GetUint("user_id"),
+		ActivityID: id,
+		Rating:     req.Rating,
+		Text:       strings.TrimSpace(req.Text),
+	}
+	created, err := h.reviews.Upsert(ctx, &review)
+	if errors.Is(err, repository.ErrNotFound) {
+		// Активность удалили после проверки
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		// Параллельный запрос успел создать отзыв первым
+		c.JSON(http.StatusConflict, gin.H{"error": "review already exists"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	h.respond(c, status, id)
+}
+
+// DELETE /api/activities/:id/review
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	err := h.reviews.Delete(c.Request.Context(), c.GetUint("user_id"), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// POST /api/reviews/:id/hide (только moderator/admin)
+// Скрытый отзыв не показывается другим и не входит в рейтинг активности
+func (h *ReviewHandler) Hide(c *gin.Context) {
+	h.setHidden(c, true)
+}
+
+// POST /api/reviews/:id/unhide (только moderator/admin)
+func (h *ReviewHandler) Unhide(c *gin.Context) {
+	h.setHidden(c, false)
+}
+
+func (h *ReviewHandler) setHidden(c *gin.Context, hidden bool) {
+	if !utils.IsModeratorOrAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+		return
+	}
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	review, err := h.reviews.SetHidden(c.Request.Context(), id, hidden)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, review)
+}
+
+// respond отвечает отзывом текущего пользователя об активности вместе с именем автора
+func (h *ReviewHandler) respond(c *gin.Context, status int, activityID uint) {
+	review, err := h.reviews.Get(c.Request.Context(), c.GetUint("user_id"), activityID)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(status, review)
+}
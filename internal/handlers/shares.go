@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/utils"
+)
+
+// shareSlugBytes — длина slug'а в случайных байтах; 16 байт не подобрать перебором
+const shareSlugBytes = 16
+
+type ShareHandler struct {
+	shares      repository.ShareRepository
+	collections repository.CollectionRepository
+	activities  repository.ActivityRepository
+}
+
+func NewShareHandler(
+	shares repository.ShareRepository,
+	collections repository.CollectionRepository,
+	activities repository.ActivityRepository,
+) *ShareHandler {
+	return &ShareHandler{shares: shares, collections: collections, activities: activities}
+}
+
+type ShareRequest struct {
+	// Через сколько дней ссылка перестанет открываться; не задано — бессрочно
+	ExpiresInDays int `json:"expires_in_days" binding:"omitempty,min=1,max=365"`
+}
+
+// SharedView — то, что видит получатель ссылки: без id владельца и служебных полей
+type SharedView struct {
+	Type       string            `json:"type"` // collection или activity
+	Collection *SharedCollection `json:"collection,omitempty"`
+	Activity   *models.Activity  `json:"activity,omitempty"`
+	ExpiresAt  *time.Time        `json:"expires_at"`
+	ViewCount  int               `json:"view_count"`
+}
+
+type SharedCollection struct {
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	Cover       string                  `json:"cover"`
+	Items       []models.CollectionItem `json:"items"`
+}
+
+// POST /api/collections/:id/share
+func (h *ShareHandler) ShareCollection(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	userID := c.GetUint("user_id")
+	_, err := h.collections.Get(c.Request.Context(), userID, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	h.create(c, models.Share{UserID: userID, CollectionID: &id})
+}
+
+// POST /api/activities/:id/share
+func (h *ShareHandler) ShareActivity(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if _, err := h.activities.Get(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	h.create(c, models.Share{UserID: c.GetUint("user_id"), ActivityID: &id})
+}
+
+// create дочитывает срок из тела запроса, выдаёт slug и сохраняет ссылку
+func (h *ShareHandler) create(c *gin.Context, share models.Share) {
+	var req ShareRequest
+	// Тело необязательное
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+			return
+		}
+	}
+	if req.ExpiresInDays > 0 {
+		expires := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		share.ExpiresAt = &expires
+	}
+	slug, err := utils.RandomToken(shareSlugBytes)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server error"})
+		return
+	}
+	share.Slug = slug
+	if err := h.shares.Create(c.Request.Context(), &share); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusCreated, share)
+}
+
+// GET /api/shares
+// Ссылки пользователя, в том числе истёкшие и отозванные
+func (h *ShareHandler) List(c *gin.Context) {
+	shares, err := h.shares.List(c.Request.Context(), c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, shares)
+}
+
+// DELETE /api/shares/:slug
+// Отзывает ссылку: она перестаёт открываться, но остаётся в списке
+func (h *ShareHandler) Revoke(c *gin.Context) {
+	err := h.shares.Revoke(c.Request.Context(), c.GetUint("user_id"), c.Param("slug"))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GET /api/public/shares/:slug
+// Открывается без авторизации. Истёкшая или отозванная ссылка — 410,
+// неизвестная или на удалённое — 404. Каждый успешный просмотр увеличивает счётчик.
+func (h *ShareHandler) View(c *gin.Context) {
+	ctx := c.Request.Context()
+	share, err := h.shares.Get(ctx, c.Param("slug"))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	if !share.Active(time.Now()) {
+		c.JSON(http.StatusGone, gin.H{"error": "link expired or revoked"})
+		return
+	}
+
+	view := SharedView{ExpiresAt: share.ExpiresAt, ViewCount: share.ViewCount + 1}
+	if share.CollectionID != nil {
+		collection, err := h.collections.Get(ctx, share.UserID, *share.CollectionID)
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+			return
+		}
+		view.Type = "collection"
+		view.Collection = &SharedCollection{
+			Name:        collection.Name,
+			Description: collection.Description,
+			Cover:       collection.Cover,
+			Items:       collection.Items,
+		}
+	} else {
+		activity, err := h.activities.Get(ctx, *share.ActivityID)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		view.Type = "activity"
+		view.Activity = activity
+	}
+	if err := h.shares.AddView(ctx, share.ID); err != nil {
+		log.Printf("count share view: %v", err)
+	}
+	c.JSON(http.StatusOK, view)
+}
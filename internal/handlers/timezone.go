@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/localtime"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+// timezoneSource определяет часовой пояс пользователя для границ дня
+type timezoneSource struct {
+	users    repository.UserRepository
+	fallback *time.Location // DEFAULT_TIMEZONE
+}
+
+// location — часовой пояс из заголовка X-Timezone, иначе из профиля, иначе по умолчанию.
+// Неверный заголовок — 400, ответ уже отправлен.
+func (t timezoneSource) location(c *gin.Context) (*time.Location, bool) {
+	if name := c.GetHeader("X-Timezone"); name != "" {
+		loc, err := localtime.Load(name)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timezone"})
+			return nil, false
+		}
+		return loc, true
+	}
+	user, err := t.users.GetByID(c.Request.Context(), c.GetUint("user_id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return nil, false
+	}
+	if loc, err := localtime.Load(user.Timezone); err == nil {
+		return loc, true
+	}
+	return t.fallback, true
+}
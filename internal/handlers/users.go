@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/localtime"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type UserHandler struct {
+	users repository.UserRepository
+}
+
+func NewUserHandler(users repository.UserRepository) *UserHandler {
+	return &UserHandler{users: users}
+}
+
+// ProfileRequest — изменяемые поля профиля; не переданные поля не меняются
+type ProfileRequest struct {
+	City     *string `json:"city" binding:"omitempty,max=128"`
+	Timezone *string `json:"timezone"` // IANA; пустая строка — часовой пояс по умолчанию
+}
+
+// GET /api/users/me
+func (h *UserHandler) Me(c *gin.Context) {
+	user, err := h.users.GetByID(c.Request.Context(), c.GetUint("user_id"))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
+
+// PUT /api/users/me — изменить город и часовой пояс
+func (h *UserHandler) UpdateMe(c *gin.Context) {
+	var req ProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request"})
+		return
+	}
+	ctx := c.Request.Context()
+	user, err := h.users.GetByID(ctx, c.GetUint("user_id"))
+	if errors.Is(err, repository.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	if req.City != nil {
+		user.City = strings.TrimSpace(*req.City)
+	}
+	if req.Timezone != nil {
+		user.Timezone = ""
+		if name := strings.TrimSpace(*req.Timezone); name != "" {
+			loc, err := localtime.Load(name)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid timezone"})
+				return
+			}
+			user.Timezone = loc.String()
+		}
+	}
+	if err := h.users.UpdateProfile(ctx, user); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "db error"})
+		return
+	}
+	c.JSON(http.StatusOK, user)
+}
@@ -0,0 +1,209 @@
+// Package insights ищет, после каких активностей у пользователя улучшается настроение.
+//
+// Настроение оценивается как окраска из словаря (1, 0 или -1), умноженная на
+// силу (1–5), так что изменение за одну активность лежит в пределах от
+// -MaxDelta до MaxDelta. Функции чистые и не зависят от порядка записей.
+package insights
+
+import (
+	"math"
+	"sort"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// MaxDelta — наибольшее по модулю изменение настроения: от -5 к 5
+const MaxDelta = 10
+
+// Effect — как в среднем меняется настроение
+type Effect struct {
+	Count    int     `json:"count"`
+	Improved int     `json:"improved"` // Сколько раз стало лучше
+	Worsened int     `json:"worsened"` // Сколько раз стало хуже
+	AvgDelta float64 `json:"avg_delta"`
+}
+
+type ActivityEffect struct {
+	ActivityID uint             `json:"activity_id"`
+	Activity   *models.Activity `json:"activity"` // nil, если активность удалена
+	Effect
+}
+
+type MoodEffect struct {
+	Mood string `json:"mood"`
+	Effect
+}
+
+// StartMoodEffect — что помогает, когда начинаешь с настроения Mood
+type StartMoodEffect struct {
+	Mood           string `json:"mood"`
+	BestActivityID *uint  `json:"best_activity_id"` // Активность с наибольшим улучшением; nil, если лучше не становилось
+	Effect
+}
+
+type Insights struct {
+	Total      int               `json:"total"`
+	AvgDelta   float64           `json:"avg_delta"`
+	Activities []ActivityEffect  `json:"activities"`  // По убыванию среднего улучшения
+	Moods      []MoodEffect      `json:"moods"`       // Настроения активностей по убыванию среднего улучшения
+	StartMoods []StartMoodEffect `json:"start_moods"` // Настроения «до»
+}
+
+// Delta — насколько изменилось настроение после активности
+func Delta(c models.ActivityCompletion, valence map[string]int) int {
+	return valence[c.MoodAfter]*c.IntensityAfter - valence[c.MoodBefore]*c.IntensityBefore
+}
+
+// Analyze собирает сводку по выполненным активностям
+func Analyze(completions []models.ActivityCompletion, valence map[string]int) Insights {
+	in := Insights{Activities: []ActivityEffect{}, Moods: []MoodEffect{}, StartMoods: []StartMoodEffect{}}
+	var total acc
+	activities := make(map[uint]*acc)
+	moods := make(map[string]*acc)
+	starts := make(map[string]*acc)
+	startActivities := make(map[string]map[uint]*acc)
+	for _, c := range completions {
+		d := Delta(c, valence)
+		total.add(d)
+		get(activities, c.ActivityID).add(d)
+		if a := activities[c.ActivityID]; a.activity == nil && c.Activity != nil {
+			a.activity = c.Activity
+		}
+		if c.Activity != nil {
+			for _, m := range c.Activity.Moods {
+				get(moods, m).add(d)
+			}
+		}
+		get(starts, c.MoodBefore).add(d)
+		if startActivities[c.MoodBefore] == nil {
+			startActivities[c.MoodBefore] = make(map[uint]*acc)
+		}
+		get(startActivities[c.MoodBefore], c.ActivityID).add(d)
+	}
+	in.Total = total.count
+	in.AvgDelta = total.effect().AvgDelta
+
+	for id, a := range activities {
+		in.Activities = append(in.Activities, ActivityEffect{ActivityID: id, Activity: a.activity, Effect: a.effect()})
+	}
+	sort.Slice(in.Activities, func(i, j int) bool {
+		if less, ok := compare(in.Activities[i].Effect, in.Activities[j].Effect); ok {
+			return less
+		}
+		return in.Activities[i].ActivityID < in.Activities[j].ActivityID
+	})
+	for m, a := range moods {
+		in.Moods = append(in.Moods, MoodEffect{Mood: m, Effect: a.effect()})
+	}
+	sort.Slice(in.Moods, func(i, j int) bool {
+		if less, ok := compare(in.Moods[i].Effect, in.Moods[j].Effect); ok {
+			return less
+		}
+		return in.Moods[i].Mood < in.Moods[j].Mood
+	})
+	for m, a := range starts {
+		s := StartMoodEffect{Mood: m, Effect: a.effect()}
+		if id, ok := best(startActivities[m]); ok {
+			s.BestActivityID = &id
+		}
+		in.StartMoods = append(in.StartMoods, s)
+	}
+	sort.Slice(in.StartMoods, func(i, j int) bool {
+		if in.StartMoods[i].Count != in.StartMoods[j].Count {
+			return in.StartMoods[i].Count > in.StartMoods[j].Count
+		}
+		return in.StartMoods[i].Mood < in.StartMoods[j].Mood
+	})
+	return in
+}
+
+// ActivityBoosts — среднее изменение настроения после каждой активности, от -1 до 1
+func ActivityBoosts(completions []models.ActivityCompletion, valence map[string]int) map[uint]float64 {
+	sums := make(map[uint]*acc)
+	for _, c := range completions {
+		get(sums, c.ActivityID).add(Delta(c, valence))
+	}
+	boosts := make(map[uint]float64, len(sums))
+	for id, a := range sums {
+		boosts[id] = a.mean() / MaxDelta
+	}
+	return boosts
+}
+
+// MoodBoosts — среднее изменение настроения после активностей с каждым
+// настроением из их описания, от -1 до 1
+func MoodBoosts(completions []models.ActivityCompletion, valence map[string]int) map[string]float64 {
+	sums := make(map[string]*acc)
+	for _, c := range completions {
+		if c.Activity == nil {
+			continue
+		}
+		d := Delta(c, valence)
+		for _, m := range c.Activity.Moods {
+			get(sums, m).add(d)
+		}
+	}
+	boosts := make(map[string]float64, len(sums))
+	for m, a := range sums {
+		boosts[m] = a.mean() / MaxDelta
+	}
+	return boosts
+}
+
+type acc struct {
+	count, improved, worsened, sum int
+	activity                       *models.Activity
+}
+
+func (a *acc) add(d int) {
+	a.count++
+	a.sum += d
+	if d > 0 {
+		a.improved++
+	} else if d < 0 {
+		a.worsened++
+	}
+}
+
+func (a *acc) mean() float64 {
+	if a.count == 0 {
+		return 0
+	}
+	return float64(a.sum) / float64(a.count)
+}
+
+func (a *acc) effect() Effect {
+	return Effect{Count: a.count, Improved: a.improved, Worsened: a.worsened, AvgDelta: math.Round(a.mean()*100) / 100}
+}
+
+func get[K comparable](m map[K]*acc, k K) *acc {
+	if m[k] == nil {
+		m[k] = &acc{}
+	}
+	return m[k]
+}
+
+// compare упорядочивает по убыванию среднего улучшения, затем по числу записей;
+// ok == false, если эффекты равны
+func compare(a, b Effect) (less, ok bool) {
+	if a.AvgDelta != b.AvgDelta {
+		return a.AvgDelta > b.AvgDelta, true
+	}
+	if a.Count != b.Count {
+		return a.Count > b.Count, true
+	}
+	return false, false
+}
+
+// best — активность с наибольшим положительным средним изменением
+func best(activities map[uint]*acc) (uint, bool) {
+	var bestID uint
+	var bestMean float64
+	for id, a := range activities {
+		m := a.mean()
+		if m > bestMean || (m == bestMean && m > 0 && id < bestID) {
+			bestID, bestMean = id, m
+		}
+	}
+	return bestID, bestMean > 0
+}
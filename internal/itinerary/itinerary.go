@@ -0,0 +1,157 @@
+// Package itinerary составляет план дня из нескольких активностей.
+//
+// Задача — выбрать не больше MaxItems активностей, суммарно укладывающихся
+// в бюджет и время, с максимальной суммарной ценностью (балл соответствия + 1,
+// чтобы при прочих равных день был заполнен плотнее). Кандидатов немного,
+// поэтому используется перебор с отсечением по верхней оценке; при равной
+// ценности предпочитается план, который занимает больше времени, затем более
+// дешёвый, затем составленный из более приоритетных кандидатов.
+package itinerary
+
+import (
+	"sort"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+const (
+	DefaultMaxItems = 4
+	// maxCandidates — сколько лучших кандидатов рассматривать при переборе
+	maxCandidates = 30
+)
+
+type Request struct {
+	Budget   int // Общий бюджет, ₽
+	Hours    int // Сколько всего есть времени, часов
+	MaxItems int // <= 0 — DefaultMaxItems
+}
+
+// Candidate — активность с баллом соответствия пользователю
+type Candidate struct {
+	Activity models.Activity
+	Score    float64
+}
+
+type Item struct {
+	Activity    models.Activity `json:"activity"`
+	Score       float64         `json:"score"`
+	StartOffset int             `json:"start_offset"` // Через сколько часов от начала плана
+}
+
+type Plan struct {
+	Items       []Item  `json:"items"`
+	TotalBudget int     `json:"total_budget"`
+	TotalTime   int     `json:"total_time"`
+	Score       float64 `json:"score"`
+}
+
+// Build возвращает лучший план; если ни одна активность не помещается, план пустой
+func Build(candidates []Candidate, req Request) Plan {
+	maxItems := req.MaxItems
+	if maxItems <= 0 {
+		maxItems = DefaultMaxItems
+	}
+
+	var pool []Candidate
+	for _, c := range candidates {
+		if c.Activity.Time > 0 && c.Activity.Time <= req.Hours && c.Activity.Budget <= req.Budget {
+			pool = append(pool, c)
+		}
+	}
+	sort.SliceStable(pool, func(i, j int) bool {
+		if pool[i].Score != pool[j].Score {
+			return pool[i].Score > pool[j].Score
+		}
+		return pool[i].Activity.ID < pool[j].Activity.ID
+	})
+	if len(pool) > maxCandidates {
+		pool = pool[:maxCandidates]
+	}
+
+	s := solver{pool: pool, req: req, maxItems: maxItems}
+	s.search(0, nil, 0, 0, 0)
+	return s.plan()
+}
+
+type solver struct {
+	pool     []Candidate
+	req      Request
+	maxItems int
+
+	best                 []int
+	bestValue            float64
+	bestTime, bestBudget int
+}
+
+func value(c Candidate) float64 {
+	return c.Score + 1
+}
+
+// search перебирает подмножества pool начиная с i. Пул отсортирован по
+// убыванию ценности, поэтому верхняя оценка — сумма следующих кандидатов
+// (отрицательная ценность, если балл ниже -1, план только ухудшает).
+func (s *solver) search(i int, chosen []int, val float64, hours, budget int) {
+	if s.better(val, hours, budget) {
+		s.best = append(s.best[:0], chosen...)
+		s.bestValue, s.bestTime, s.bestBudget = val, hours, budget
+	}
+	if len(chosen) == s.maxItems {
+		return
+	}
+	bound := val
+	for j := i; j < len(s.pool) && j < i+s.maxItems-len(chosen); j++ {
+		bound += max(value(s.pool[j]), 0)
+	}
+	if bound < s.bestValue {
+		return
+	}
+	for j := i; j < len(s.pool); j++ {
+		a := s.pool[j].Activity
+		if hours+a.Time > s.req.Hours || budget+a.Budget > s.req.Budget {
+			continue
+		}
+		s.search(j+1, append(chosen, j), val+value(s.pool[j]), hours+a.Time, budget+a.Budget)
+	}
+}
+
+func (s *solver) better(val float64, hours, budget int) bool {
+	const eps = 1e-9
+	switch {
+	case val > s.bestValue+eps:
+		return true
+	case val < s.bestValue-eps:
+		return false
+	case hours != s.bestTime:
+		return hours > s.bestTime
+	}
+	return budget < s.bestBudget
+}
+
+// plan упорядочивает выбранное: сначала то, что зависит от погоды (пока светло),
+// затем более длинные активности, и проставляет время начала
+func (s *solver) plan() Plan {
+	chosen := make([]Candidate, len(s.best))
+	for i, idx := range s.best {
+		chosen[i] = s.pool[idx]
+	}
+	sort.SliceStable(chosen, func(i, j int) bool {
+		oi, oj := weather.Outdoor(chosen[i].Activity.Weather), weather.Outdoor(chosen[j].Activity.Weather)
+		if oi != oj {
+			return oi
+		}
+		if chosen[i].Activity.Time != chosen[j].Activity.Time {
+			return chosen[i].Activity.Time > chosen[j].Activity.Time
+		}
+		return chosen[i].Activity.ID < chosen[j].Activity.ID
+	})
+
+	p := Plan{Items: []Item{}}
+	for _, c := range chosen {
+		p.Items = append(p.Items, Item{Activity: c.Activity, Score: c.Score, StartOffset: p.TotalTime})
+		p.TotalTime += c.Activity.Time
+		p.TotalBudget += c.Activity.Budget
+		p.Score += c.Score
+	}
+	return p
+}
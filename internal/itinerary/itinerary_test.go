@@ -0,0 +1,177 @@
+package itinerary
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// candidate — активность в любую погоду
+func candidate(id uint, budget, hours int, score float64) Candidate {
+	return Candidate{Activity: models.Activity{ID: id, Budget: budget, Time: hours, Weather: []string{"any"}}, Score: score}
+}
+
+func outdoor(c Candidate) Candidate {
+	c.Activity.Weather = []string{"sunny"}
+	return c
+}
+
+func ids(p Plan) []uint {
+	result := make([]uint, len(p.Items))
+	for i, item := range p.Items {
+		result[i] = item.Activity.ID
+	}
+	return result
+}
+
+func TestBuild(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []Candidate
+		req        Request
+		want       []uint // в порядке плана: при равной длительности по id
+	}{
+		{
+			name:       "empty pool",
+			candidates: nil,
+			req:        Request{Budget: 1000, Hours: 8},
+			want:       []uint{},
+		},
+		{
+			name:       "nothing fits",
+			candidates: []Candidate{candidate(1, 2000, 2, 1), candidate(2, 100, 10, 1)},
+			req:        Request{Budget: 1000, Hours: 8},
+			want:       []uint{},
+		},
+		{
+			name:       "activities without duration are skipped",
+			candidates: []Candidate{candidate(1, 0, 0, 5)},
+			req:        Request{Budget: 1000, Hours: 8},
+			want:       []uint{},
+		},
+		{
+			name:       "budget limit",
+			candidates: []Candidate{candidate(1, 600, 1, 2), candidate(2, 500, 1, 2), candidate(3, 400, 1, 1)},
+			req:        Request{Budget: 1000, Hours: 8},
+			want:       []uint{2, 3},
+		},
+		{
+			name:       "budget limit is inclusive",
+			candidates: []Candidate{candidate(1, 600, 1, 1), candidate(2, 400, 1, 1)},
+			req:        Request{Budget: 1000, Hours: 8},
+			want:       []uint{1, 2},
+		},
+		{
+			name:       "hours limit",
+			candidates: []Candidate{candidate(1, 0, 5, 3), candidate(2, 0, 4, 2), candidate(3, 0, 3, 2)},
+			req:        Request{Budget: 0, Hours: 8},
+			want:       []uint{1, 3},
+		},
+		{
+			name:       "higher total beats a single best",
+			candidates: []Candidate{candidate(1, 0, 6, 4), candidate(2, 0, 3, 2), candidate(3, 0, 3, 2)},
+			req:        Request{Budget: 0, Hours: 6},
+			want:       []uint{2, 3},
+		},
+		{
+			name: "default max items",
+			candidates: []Candidate{
+				candidate(1, 0, 1, 1), candidate(2, 0, 1, 1), candidate(3, 0, 1, 1),
+				candidate(4, 0, 1, 1), candidate(5, 0, 1, 1), candidate(6, 0, 1, 2),
+			},
+			req:  Request{Budget: 0, Hours: 8},
+			want: []uint{1, 2, 3, 6},
+		},
+		{
+			name:       "max items",
+			candidates: []Candidate{candidate(1, 0, 1, 1), candidate(2, 0, 1, 3), candidate(3, 0, 1, 2)},
+			req:        Request{Budget: 0, Hours: 8, MaxItems: 2},
+			want:       []uint{2, 3},
+		},
+		{
+			name:       "tie: more time wins",
+			candidates: []Candidate{candidate(1, 0, 2, 1), candidate(2, 0, 3, 1)},
+			req:        Request{Budget: 0, Hours: 8, MaxItems: 1},
+			want:       []uint{2},
+		},
+		{
+			name:       "tie: then cheaper",
+			candidates: []Candidate{candidate(1, 500, 2, 1), candidate(2, 300, 2, 1)},
+			req:        Request{Budget: 1000, Hours: 8, MaxItems: 1},
+			want:       []uint{2},
+		},
+		{
+			name:       "tie: then lower id",
+			candidates: []Candidate{candidate(3, 300, 2, 1), candidate(1, 300, 2, 1), candidate(2, 300, 2, 1)},
+			req:        Request{Budget: 1000, Hours: 8, MaxItems: 1},
+			want:       []uint{1},
+		},
+		{
+			// Ценность — балл + 1: балл ниже -1 только ухудшает план
+			name:       "negative scores are skipped",
+			candidates: []Candidate{candidate(1, 0, 1, -3), candidate(2, 0, 1, -1.5), candidate(3, 0, 1, 1)},
+			req:        Request{Budget: 0, Hours: 8},
+			want:       []uint{3},
+		},
+		{
+			name:       "slightly negative score still fills the day",
+			candidates: []Candidate{candidate(1, 0, 1, -0.5), candidate(2, 0, 1, 1)},
+			req:        Request{Budget: 0, Hours: 8},
+			want:       []uint{1, 2},
+		},
+		{
+			name:       "only negative scores",
+			candidates: []Candidate{candidate(1, 0, 1, -2), candidate(2, 0, 2, -5)},
+			req:        Request{Budget: 0, Hours: 8},
+			want:       []uint{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := Build(tt.candidates, tt.req)
+			if p.Items == nil {
+				t.Fatal("items is nil, want an empty list")
+			}
+			if got := ids(p); !slices.Equal(got, tt.want) {
+				t.Errorf("plan = %v, want %v", got, tt.want)
+			}
+			if p.TotalBudget > tt.req.Budget || p.TotalTime > tt.req.Hours {
+				t.Errorf("plan exceeds limits: %d ₽, %d h", p.TotalBudget, p.TotalTime)
+			}
+		})
+	}
+}
+
+func TestBuildOrderAndOffsets(t *testing.T) {
+	candidates := []Candidate{
+		candidate(1, 300, 1, 1),
+		candidate(2, 200, 3, 1),
+		outdoor(candidate(3, 0, 2, 1)),
+		outdoor(candidate(4, 500, 1, 1)),
+	}
+	p := Build(candidates, Request{Budget: 1000, Hours: 8})
+
+	// Сначала активности на улице, затем более длинные, при равной длине — по id
+	want := []struct {
+		id          uint
+		startOffset int
+	}{
+		{3, 0},
+		{4, 2},
+		{2, 3},
+		{1, 6},
+	}
+	if len(p.Items) != len(want) {
+		t.Fatalf("plan = %v, want %d items", ids(p), len(want))
+	}
+	for i, w := range want {
+		item := p.Items[i]
+		if item.Activity.ID != w.id || item.StartOffset != w.startOffset {
+			t.Errorf("item %d: activity %d at +%d h, want %d at +%d h", i, item.Activity.ID, item.StartOffset, w.id, w.startOffset)
+		}
+	}
+	if p.TotalBudget != 1000 || p.TotalTime != 7 || p.Score != 4 {
+		t.Errorf("totals: %d ₽, %d h, score %v; want 1000 ₽, 7 h, score 4", p.TotalBudget, p.TotalTime, p.Score)
+	}
+}
@@ -0,0 +1,39 @@
+// Package localtime переводит моменты времени в календарные дни пользователя.
+//
+// Календарный день (колонки DATE, MoodEntry.Date) представляется полночью
+// по UTC с нужными годом, месяцем и числом — так его возвращает PostgreSQL,
+// поэтому дни из базы и посчитанные здесь можно сравнивать напрямую.
+package localtime
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalid = errors.New("invalid timezone")
+
+// Load загружает часовой пояс по имени IANA (Europe/Moscow); пустое имя — ошибка
+func Load(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	// "Local" зависит от настроек сервера, а не пользователя
+	if name == "" || name == "Local" {
+		return nil, ErrInvalid
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	return loc, nil
+}
+
+// Day — календарный день, на который приходится t в часовом поясе loc
+func Day(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Start — момент, когда календарный день day начинается в часовом поясе loc
+func Start(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+}
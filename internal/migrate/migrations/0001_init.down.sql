@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS mood_stats;
+DROP TABLE IF EXISTS histories;
+DROP TABLE IF EXISTS history;
+DROP TABLE IF EXISTS favorites;
+DROP TABLE IF EXISTS activities;
+DROP TABLE IF EXISTS users;
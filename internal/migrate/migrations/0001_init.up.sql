@@ -0,0 +1,49 @@
+-- Базовая схема. IF NOT EXISTS нужен для баз, созданных ещё до появления миграций.
+CREATE TABLE IF NOT EXISTS users (
+	id SERIAL PRIMARY KEY,
+	username VARCHAR(64) UNIQUE NOT NULL,
+	password_hash VARCHAR(128) NOT NULL,
+	role VARCHAR(16) DEFAULT 'user',
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS activities (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(128) NOT NULL,
+	description TEXT,
+	budget INT,
+	time INT,
+	weather VARCHAR(16),
+	people_count INT DEFAULT 1,
+	moods VARCHAR(64)[],
+	created_at TIMESTAMP DEFAULT NOW(),
+	deleted_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS favorites (
+	user_id INT REFERENCES users(id),
+	activity_id INT REFERENCES activities(id),
+	PRIMARY KEY (user_id, activity_id)
+);
+
+CREATE TABLE IF NOT EXISTS history (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id),
+	activity_id INT REFERENCES activities(id),
+	viewed_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS histories (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id),
+	activity_id INT REFERENCES activities(id),
+	viewed_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS mood_stats (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	date DATE NOT NULL,
+	mood VARCHAR(64) NOT NULL,
+	UNIQUE (user_id, date)
+);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id VARCHAR(32) PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	created_at TIMESTAMP DEFAULT NOW(),
+	revoked_at TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+	id SERIAL PRIMARY KEY,
+	session_id VARCHAR(32) NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+	user_id INT NOT NULL REFERENCES users(id),
+	token_hash VARCHAR(64) UNIQUE NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	used_at TIMESTAMP,
+	created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_activities_name_trgm;
+DROP INDEX IF EXISTS idx_activities_search_vector;
+ALTER TABLE activities DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,13 @@
+-- Полнотекстовый поиск по названию (вес A) и описанию (вес B) с русской морфологией
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS search_vector tsvector
+	GENERATED ALWAYS AS (
+		setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('russian', coalesce(description, '')), 'B')
+	) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_activities_search_vector ON activities USING GIN (search_vector);
+
+-- Триграммы нужны автодополнению, чтобы находить названия с опечатками
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_activities_name_trgm ON activities USING GIN (lower(name) gin_trgm_ops);
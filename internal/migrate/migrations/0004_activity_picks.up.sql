@@ -0,0 +1,9 @@
+-- Что выдавал /api/activities/random, чтобы не повторять последние выборы
+CREATE TABLE IF NOT EXISTS activity_picks (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	activity_id INT NOT NULL REFERENCES activities(id),
+	picked_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_picks_user_picked_at ON activity_picks (user_id, picked_at DESC);
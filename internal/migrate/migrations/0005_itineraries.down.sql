@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS itinerary_items;
+DROP TABLE IF EXISTS itineraries;
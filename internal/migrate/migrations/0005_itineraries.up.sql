@@ -0,0 +1,26 @@
+-- Сохранённые планы дня (POST /api/itineraries)
+CREATE TABLE IF NOT EXISTS itineraries (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	title VARCHAR(128) NOT NULL,
+	budget INT NOT NULL,
+	hours INT NOT NULL,
+	mood VARCHAR(64),
+	people_count INT,
+	total_budget INT NOT NULL,
+	total_time INT NOT NULL,
+	score DOUBLE PRECISION NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_itineraries_user_id ON itineraries (user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS itinerary_items (
+	id SERIAL PRIMARY KEY,
+	itinerary_id INT NOT NULL REFERENCES itineraries(id) ON DELETE CASCADE,
+	activity_id INT NOT NULL REFERENCES activities(id),
+	position INT NOT NULL,
+	start_offset INT NOT NULL,
+	score DOUBLE PRECISION NOT NULL,
+	UNIQUE (itinerary_id, position)
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_activities_time;
+ALTER TABLE activities DROP CONSTRAINT IF EXISTS activities_people_range_check;
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS people_count INT DEFAULT 1;
+UPDATE activities SET people_count = COALESCE(max_people, min_people);
+ALTER TABLE activities DROP COLUMN IF EXISTS max_people;
+ALTER TABLE activities DROP COLUMN IF EXISTS min_people;
@@ -0,0 +1,15 @@
+-- Вместо точного числа людей активность задаёт диапазон размера компании.
+-- Старое people_count переносится как есть: 1–4 — ровно столько, 5 и больше — «от N».
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS min_people INT NOT NULL DEFAULT 1;
+ALTER TABLE activities ADD COLUMN IF NOT EXISTS max_people INT;
+
+UPDATE activities SET
+	min_people = GREATEST(COALESCE(people_count, 1), 1),
+	max_people = CASE WHEN COALESCE(people_count, 1) >= 5 THEN NULL ELSE GREATEST(COALESCE(people_count, 1), 1) END;
+
+ALTER TABLE activities DROP COLUMN IF EXISTS people_count;
+
+ALTER TABLE activities ADD CONSTRAINT activities_people_range_check
+	CHECK (min_people >= 1 AND (max_people IS NULL OR max_people >= min_people));
+
+CREATE INDEX IF NOT EXISTS idx_activities_time ON activities (time);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_activities_weather;
+ALTER TABLE activities DROP CONSTRAINT IF EXISTS activities_weather_check;
+ALTER TABLE activities ALTER COLUMN weather DROP NOT NULL;
+ALTER TABLE activities ALTER COLUMN weather DROP DEFAULT;
+-- Из набора остаётся первое условие
+ALTER TABLE activities ALTER COLUMN weather TYPE VARCHAR(16) USING weather[1];
@@ -0,0 +1,15 @@
+-- Погода активности — набор условий; 'any' означает «в любую погоду».
+-- Пустые и неизвестные значения становятся 'any'.
+ALTER TABLE activities ALTER COLUMN weather DROP DEFAULT;
+ALTER TABLE activities ALTER COLUMN weather TYPE VARCHAR(16)[] USING
+	CASE WHEN lower(btrim(weather)) IN ('sunny', 'cloudy', 'rainy', 'snowy')
+		THEN ARRAY[lower(btrim(weather))]
+		ELSE ARRAY['any']
+	END::VARCHAR(16)[];
+ALTER TABLE activities ALTER COLUMN weather SET DEFAULT '{any}';
+ALTER TABLE activities ALTER COLUMN weather SET NOT NULL;
+
+ALTER TABLE activities ADD CONSTRAINT activities_weather_check
+	CHECK (cardinality(weather) > 0 AND weather <@ ARRAY['sunny', 'cloudy', 'rainy', 'snowy', 'any']::VARCHAR(16)[]);
+
+CREATE INDEX IF NOT EXISTS idx_activities_weather ON activities USING GIN (weather);
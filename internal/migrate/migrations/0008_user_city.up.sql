@@ -0,0 +1,2 @@
+-- Город пользователя, чтобы подставлять текущую погоду (weather=auto)
+ALTER TABLE users ADD COLUMN IF NOT EXISTS city VARCHAR(128) NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_activities_moods;
+
+UPDATE mood_stats s SET mood = m.label_ru FROM moods m WHERE s.mood = m.slug;
+
+UPDATE activities a SET moods = ARRAY(
+	SELECT COALESCE(m.label_ru, u.slug)
+	FROM unnest(a.moods) WITH ORDINALITY AS u(slug, n)
+	LEFT JOIN moods m ON m.slug = u.slug
+	ORDER BY u.n
+)::VARCHAR(64)[]
+WHERE moods IS NOT NULL;
+
+DROP TABLE IF EXISTS moods;
@@ -0,0 +1,40 @@
+-- Словарь настроений; в activities.moods и mood_stats.mood теперь хранятся slug'и
+CREATE TABLE IF NOT EXISTS moods (
+	slug VARCHAR(32) PRIMARY KEY,
+	label_ru VARCHAR(64) NOT NULL,
+	label_en VARCHAR(64) NOT NULL,
+	emoji VARCHAR(16),
+	color VARCHAR(7),
+	position INT NOT NULL DEFAULT 0
+);
+
+INSERT INTO moods (slug, label_ru, label_en, emoji, color, position) VALUES
+	('cheerful', 'Весело', 'Cheerful', '😄', '#FFC107', 1),
+	('calm', 'Спокойно', 'Calm', '😌', '#4FC3F7', 2),
+	('inspired', 'Вдохновенно', 'Inspired', '✨', '#BA68C8', 3),
+	('neutral', 'Нейтрально', 'Neutral', '😐', '#B0BEC5', 4),
+	('good', 'Хорошо', 'Good', '🙂', '#81C784', 5),
+	('romantic', 'Романтично', 'Romantic', '💕', '#F06292', 6),
+	('friendly', 'Дружелюбно', 'Friendly', '🤗', '#FFB74D', 7),
+	('curious', 'Интересно', 'Curious', '🧐', '#7986CB', 8),
+	('active', 'Активно', 'Active', '⚡', '#FF7043', 9),
+	('sad', 'Грустно', 'Sad', '😢', '#90A4AE', 10),
+	('relaxed', 'Расслабленно', 'Relaxed', '🛋️', '#A1887F', 11)
+ON CONFLICT (slug) DO NOTHING;
+
+-- Подписи активностей -> slug'и с сохранением порядка; значения не из словаря отбрасываются
+UPDATE activities a SET moods = ARRAY(
+	SELECT m.slug
+	FROM unnest(a.moods) WITH ORDINALITY AS u(label, n)
+	JOIN moods m ON lower(btrim(u.label)) IN (m.slug, lower(m.label_ru), lower(m.label_en))
+	GROUP BY m.slug
+	ORDER BY min(u.n)
+)::VARCHAR(64)[]
+WHERE moods IS NOT NULL;
+
+-- Отметки настроения -> slug'и; неизвестные оставляем как есть
+UPDATE mood_stats s SET mood = m.slug
+FROM moods m
+WHERE lower(btrim(s.mood)) IN (lower(m.label_ru), lower(m.label_en));
+
+CREATE INDEX IF NOT EXISTS idx_activities_moods ON activities USING GIN (moods);
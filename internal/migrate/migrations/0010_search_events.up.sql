@@ -0,0 +1,13 @@
+-- Поиски по списку активностей; раньше поиск по настроению писался в mood_stats
+CREATE TABLE IF NOT EXISTS search_events (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	query VARCHAR(256) NOT NULL DEFAULT '',
+	moods VARCHAR(64)[] NOT NULL DEFAULT '{}',
+	params TEXT NOT NULL DEFAULT '',
+	result_count BIGINT NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_events_user_created_at ON search_events (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_events_created_at ON search_events (created_at);
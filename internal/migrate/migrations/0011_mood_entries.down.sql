@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS mood_stats (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	date DATE NOT NULL,
+	mood VARCHAR(64) NOT NULL,
+	UNIQUE (user_id, date)
+);
+
+-- Из каждого дня остаётся последняя запись
+INSERT INTO mood_stats (user_id, date, mood)
+SELECT DISTINCT ON (user_id, date) user_id, date, mood
+FROM mood_entries
+ORDER BY user_id, date, logged_at DESC, id DESC;
+
+DROP TABLE IF EXISTS mood_entries;
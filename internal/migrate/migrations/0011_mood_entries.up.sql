@@ -0,0 +1,19 @@
+-- Дневник настроения: несколько записей в день с силой, заметкой и тегами.
+-- Отметка «настроение за день» теперь — последняя запись дня.
+CREATE TABLE IF NOT EXISTS mood_entries (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	date DATE NOT NULL,
+	logged_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	mood VARCHAR(64) NOT NULL,
+	intensity SMALLINT NOT NULL DEFAULT 3 CHECK (intensity BETWEEN 1 AND 5),
+	note TEXT NOT NULL DEFAULT '',
+	tags VARCHAR(32)[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_mood_entries_user_date ON mood_entries (user_id, date, logged_at);
+
+INSERT INTO mood_entries (user_id, date, logged_at, mood)
+SELECT user_id, date, date::TIMESTAMP, mood FROM mood_stats ORDER BY id;
+
+DROP TABLE IF EXISTS mood_stats;
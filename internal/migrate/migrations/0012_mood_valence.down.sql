@@ -0,0 +1 @@
+ALTER TABLE moods DROP COLUMN IF EXISTS valence;
@@ -0,0 +1,6 @@
+-- Окраска настроения для аналитики: 1 — приятное, -1 — неприятное, 0 — нейтральное
+ALTER TABLE moods ADD COLUMN IF NOT EXISTS valence SMALLINT NOT NULL DEFAULT 0
+	CHECK (valence BETWEEN -1 AND 1);
+
+UPDATE moods SET valence = 1 WHERE slug IN ('cheerful', 'calm', 'inspired', 'good', 'romantic', 'friendly', 'relaxed');
+UPDATE moods SET valence = -1 WHERE slug IN ('sad');
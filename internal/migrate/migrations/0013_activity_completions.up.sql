@@ -0,0 +1,14 @@
+-- Выполненные активности с настроением до и после
+CREATE TABLE IF NOT EXISTS activity_completions (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	activity_id INT NOT NULL REFERENCES activities(id),
+	mood_before VARCHAR(64) NOT NULL,
+	intensity_before SMALLINT NOT NULL DEFAULT 3 CHECK (intensity_before BETWEEN 1 AND 5),
+	mood_after VARCHAR(64) NOT NULL,
+	intensity_after SMALLINT NOT NULL DEFAULT 3 CHECK (intensity_after BETWEEN 1 AND 5),
+	note TEXT NOT NULL DEFAULT '',
+	completed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_completions_user_completed_at ON activity_completions (user_id, completed_at DESC);
@@ -0,0 +1,2 @@
+-- Часовой пояс пользователя (IANA) для границ дня; пусто — DEFAULT_TIMEZONE
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT '';
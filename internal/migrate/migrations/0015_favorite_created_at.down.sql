@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_favorites_user_created;
+ALTER TABLE favorites DROP COLUMN IF EXISTS created_at;
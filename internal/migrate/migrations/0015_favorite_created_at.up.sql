@@ -0,0 +1,3 @@
+-- Когда активность добавили в избранное; у старых записей времени нет, считаем временем миграции
+ALTER TABLE favorites ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+CREATE INDEX IF NOT EXISTS idx_favorites_user_created ON favorites (user_id, created_at DESC);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS favorites (
+	user_id INT REFERENCES users(id),
+	activity_id INT REFERENCES activities(id),
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (user_id, activity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_favorites_user_created ON favorites (user_id, created_at DESC);
+
+-- Возвращается только избранное, остальные коллекции теряются
+INSERT INTO favorites (user_id, activity_id, created_at)
+SELECT c.user_id, i.activity_id, i.created_at
+FROM collection_items i
+JOIN collections c ON c.id = i.collection_id AND c.is_default
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS collection_items;
+DROP TABLE IF EXISTS collections;
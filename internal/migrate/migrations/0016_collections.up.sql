@@ -0,0 +1,39 @@
+-- Коллекции активностей пользователя
+CREATE TABLE IF NOT EXISTS collections (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	name VARCHAR(128) NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	cover VARCHAR(512) NOT NULL DEFAULT '',
+	is_default BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_collections_user ON collections (user_id);
+-- Коллекция по умолчанию (избранное) у пользователя одна
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_user_default ON collections (user_id) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS collection_items (
+	collection_id INT NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+	activity_id INT NOT NULL REFERENCES activities(id),
+	position INT NOT NULL,
+	note TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (collection_id, activity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_items_activity ON collection_items (activity_id);
+
+-- Избранное переезжает в коллекцию по умолчанию в порядке добавления
+INSERT INTO collections (user_id, name, is_default, created_at, updated_at)
+SELECT user_id, 'Избранное', TRUE, MIN(created_at), MAX(created_at) FROM favorites GROUP BY user_id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO collection_items (collection_id, activity_id, position, created_at)
+SELECT c.id, f.activity_id, ROW_NUMBER() OVER (PARTITION BY f.user_id ORDER BY f.created_at, f.activity_id), f.created_at
+FROM favorites f
+JOIN collections c ON c.user_id = f.user_id AND c.is_default
+ON CONFLICT DO NOTHING;
+
+DROP TABLE IF EXISTS favorites;
@@ -0,0 +1,15 @@
+-- Публичные ссылки на коллекции и активности
+CREATE TABLE IF NOT EXISTS shares (
+	id SERIAL PRIMARY KEY,
+	slug VARCHAR(32) NOT NULL UNIQUE,
+	user_id INT NOT NULL REFERENCES users(id),
+	collection_id INT REFERENCES collections(id) ON DELETE CASCADE,
+	activity_id INT REFERENCES activities(id),
+	expires_at TIMESTAMP,
+	revoked_at TIMESTAMP,
+	view_count INT NOT NULL DEFAULT 0,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	CHECK ((collection_id IS NULL) <> (activity_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_shares_user_created ON shares (user_id, created_at DESC);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_histories_user_viewed;
+
+ALTER TABLE histories
+	ALTER COLUMN user_id DROP NOT NULL,
+	ALTER COLUMN activity_id DROP NOT NULL,
+	ALTER COLUMN viewed_at DROP NOT NULL;
+
+-- Таблица возвращается пустой: все просмотры остаются в histories
+CREATE TABLE IF NOT EXISTS history (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id),
+	activity_id INT REFERENCES activities(id),
+	viewed_at TIMESTAMP DEFAULT NOW()
+);
@@ -0,0 +1,25 @@
+-- history осталась от первой схемы, а модель пишет в histories. Переносим оттуда
+-- просмотры, которых в histories ещё нет, и удаляем лишнюю таблицу.
+-- Строки без пользователя, активности или времени показать нельзя — они не переносятся.
+INSERT INTO histories (user_id, activity_id, viewed_at)
+SELECT h.user_id, h.activity_id, h.viewed_at
+FROM history h
+WHERE h.user_id IS NOT NULL AND h.activity_id IS NOT NULL AND h.viewed_at IS NOT NULL
+	AND NOT EXISTS (
+		SELECT 1 FROM histories x
+		WHERE x.user_id = h.user_id AND x.activity_id = h.activity_id AND x.viewed_at = h.viewed_at
+	)
+ORDER BY h.viewed_at, h.id;
+
+DROP TABLE IF EXISTS history;
+
+DELETE FROM histories WHERE user_id IS NULL OR activity_id IS NULL OR viewed_at IS NULL;
+ALTER TABLE histories
+	ALTER COLUMN user_id SET NOT NULL,
+	ALTER COLUMN activity_id SET NOT NULL,
+	ALTER COLUMN viewed_at SET NOT NULL;
+
+-- История всегда читается по пользователю от новых к старым; индекс по одному user_id
+-- мог остаться от gorm AutoMigrate и теперь не нужен
+DROP INDEX IF EXISTS idx_histories_user_id;
+CREATE INDEX IF NOT EXISTS idx_histories_user_viewed ON histories (user_id, viewed_at DESC);
@@ -0,0 +1,5 @@
+ALTER TABLE activities
+	DROP COLUMN IF EXISTS rating_avg,
+	DROP COLUMN IF EXISTS rating_count;
+
+DROP TABLE IF EXISTS reviews;
@@ -0,0 +1,19 @@
+-- Оценки и отзывы об активностях
+CREATE TABLE IF NOT EXISTS reviews (
+	id SERIAL PRIMARY KEY,
+	user_id INT NOT NULL REFERENCES users(id),
+	activity_id INT NOT NULL REFERENCES activities(id),
+	rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+	text TEXT NOT NULL DEFAULT '',
+	hidden BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	UNIQUE (user_id, activity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviews_activity_created ON reviews (activity_id, created_at DESC);
+
+-- Средняя оценка и число видимых отзывов хранятся в активности, чтобы сортировать по ним без подзапросов
+ALTER TABLE activities
+	ADD COLUMN IF NOT EXISTS rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS rating_count INT NOT NULL DEFAULT 0;
@@ -12,10 +12,31 @@ type Activity struct {
 	Name        string         `gorm:"not null;size:128" json:"name"`
 	Description string         `json:"description"`
 	Budget      int            `json:"budget"`
-	Time        int            `json:"time"` // Сколько времени займёт (в часах)
-	Weather     string         `gorm:"size:16" json:"weather"`
-	PeopleCount int            `json:"people_count"` // Количество людей (1, 2, 3, 4, 5+)
+	Time        int            `json:"time"`                                                       // Сколько времени займёт (в часах)
+	Weather     pq.StringArray `gorm:"type:varchar(16)[];not null;default:'{any}'" json:"weather"` // Подходящая погода, см. пакет weather
+	MinPeople   int            `gorm:"not null;default:1" json:"min_people"`                       // На компанию от скольких человек
+	MaxPeople   *int           `json:"max_people"`                                                 // До скольких человек; nil — без ограничения
 	Moods       pq.StringArray `gorm:"type:varchar(64)[]" json:"moods"`
 	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
 	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	// Средняя оценка и число видимых отзывов; пересчитывает ReviewRepository, через активность не пишутся
+	RatingAvg   float64 `gorm:"->" json:"rating_avg"`
+	RatingCount int     `gorm:"->" json:"rating_count"`
+
+	// Заполняются только при полнотекстовом поиске (q=), в БД не пишутся
+	SearchRank float64 `gorm:"->" json:"search_rank,omitempty"`
+	Headline   string  `gorm:"->" json:"headline,omitempty"` // Фрагмент текста с найденными словами в <b>…</b>
+	// Заполняется только в списке избранного: когда активность туда добавили
+	FavoritedAt *time.Time `gorm:"->" json:"favorited_at,omitempty"`
+}
+
+// FitsGroup проверяет, подходит ли активность для компании из n человек
+func (a Activity) FitsGroup(n int) bool {
+	return n >= a.MinPeople && (a.MaxPeople == nil || n <= *a.MaxPeople)
+}
+
+// ActivitySuggestion — вариант автодополнения по названию
+type ActivitySuggestion struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
 }
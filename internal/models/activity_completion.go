@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ActivityCompletion — пользователь сделал активность и отметил настроение до и после
+type ActivityCompletion struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	UserID          uint      `gorm:"not null;index" json:"user_id"`
+	ActivityID      uint      `gorm:"not null" json:"activity_id"`
+	MoodBefore      string    `gorm:"size:64;not null" json:"mood_before"`
+	IntensityBefore int       `gorm:"not null;default:3" json:"intensity_before"` // 1–5
+	MoodAfter       string    `gorm:"size:64;not null" json:"mood_after"`
+	IntensityAfter  int       `gorm:"not null;default:3" json:"intensity_after"` // 1–5
+	Note            string    `json:"note"`
+	CompletedAt     time.Time `gorm:"not null" json:"completed_at"`
+	Activity        *Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"` // nil, если активность удалена
+}
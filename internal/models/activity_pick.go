@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// ActivityPick — активность, выданная пользователю случайным выбором
+type ActivityPick struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;index" json:"user_id"`
+	ActivityID uint      `gorm:"not null" json:"activity_id"`
+	PickedAt   time.Time `gorm:"autoCreateTime" json:"picked_at"`
+}
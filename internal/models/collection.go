@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// DefaultCollectionName — название коллекции по умолчанию, в которой хранится избранное
+const DefaultCollectionName = "Избранное"
+
+// Collection — именованный список активностей пользователя («Выходные с детьми», «Дождливый день»).
+// У каждого пользователя не больше одной коллекции по умолчанию (IsDefault) — это его избранное.
+type Collection struct {
+	ID          uint             `gorm:"primaryKey" json:"id"`
+	UserID      uint             `gorm:"not null;index" json:"user_id"`
+	Name        string           `gorm:"size:128;not null" json:"name"`
+	Description string           `gorm:"not null;default:''" json:"description"`
+	Cover       string           `gorm:"size:512;not null;default:''" json:"cover"` // URL обложки
+	IsDefault   bool             `gorm:"not null;default:false" json:"is_default"`
+	CreatedAt   time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
+	ItemCount   int              `gorm:"->" json:"item_count"` // Сколько в коллекции неудалённых активностей, в БД не пишется
+	Items       []CollectionItem `gorm:"foreignKey:CollectionID" json:"items,omitempty"`
+}
+
+// CollectionItem — активность на своём месте в коллекции
+type CollectionItem struct {
+	CollectionID uint      `gorm:"primaryKey" json:"-"`
+	ActivityID   uint      `gorm:"primaryKey" json:"activity_id"`
+	Position     int       `gorm:"not null" json:"position"`
+	Note         string    `gorm:"not null;default:''" json:"note"`
+	CreatedAt    time.Time `gorm:"autoCreateTime" json:"added_at"`
+	Activity     *Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"`
+}
@@ -4,7 +4,8 @@ import "time"
 
 type History struct {
 	ID         uint      `gorm:"primaryKey" json:"id"`
-	UserID     uint      `gorm:"index" json:"user_id"`
-	ActivityID uint      `gorm:"index" json:"activity_id"`
-	ViewedAt   time.Time `gorm:"autoCreateTime" json:"viewed_at"`
+	UserID     uint      `gorm:"not null;index:idx_histories_user_viewed,priority:1" json:"user_id"`
+	ActivityID uint      `gorm:"not null" json:"activity_id"`
+	ViewedAt   time.Time `gorm:"not null;autoCreateTime;index:idx_histories_user_viewed,priority:2,sort:desc" json:"viewed_at"`
+	Activity   *Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"` // Подгружается не везде; nil, если активность удалена
 }
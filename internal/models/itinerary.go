@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Itinerary — сохранённый план дня из нескольких активностей
+type Itinerary struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	UserID      uint            `gorm:"not null;index" json:"user_id"`
+	Title       string          `gorm:"size:128;not null" json:"title"`
+	Budget      int             `gorm:"not null" json:"budget"` // Лимит бюджета, ₽
+	Hours       int             `gorm:"not null" json:"hours"`  // Лимит времени, часов
+	Mood        string          `gorm:"size:64" json:"mood"`
+	PeopleCount *int            `json:"people_count"`
+	TotalBudget int             `gorm:"not null" json:"total_budget"`
+	TotalTime   int             `gorm:"not null" json:"total_time"`
+	Score       float64         `gorm:"not null" json:"score"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+	Items       []ItineraryItem `gorm:"foreignKey:ItineraryID" json:"items"`
+}
+
+// ItineraryItem — активность на своём месте в плане
+type ItineraryItem struct {
+	ID          uint      `gorm:"primaryKey" json:"-"`
+	ItineraryID uint      `gorm:"not null;index" json:"-"`
+	ActivityID  uint      `gorm:"not null" json:"activity_id"`
+	Position    int       `gorm:"not null" json:"position"`
+	StartOffset int       `gorm:"not null" json:"start_offset"` // Через сколько часов от начала плана
+	Score       float64   `gorm:"not null" json:"score"`
+	Activity    *Activity `gorm:"foreignKey:ActivityID" json:"activity,omitempty"` // nil, если активность удалена
+}
@@ -0,0 +1,12 @@
+package models
+
+// Mood — настроение из словаря; в активностях и статистике хранится его Slug
+type Mood struct {
+	Slug     string `gorm:"primaryKey;size:32" json:"slug"`
+	LabelRu  string `gorm:"size:64;not null" json:"label_ru"`
+	LabelEn  string `gorm:"size:64;not null" json:"label_en"`
+	Emoji    string `gorm:"size:16" json:"emoji"`
+	Color    string `gorm:"size:7" json:"color"` // #RRGGBB
+	Position int    `gorm:"not null;default:0" json:"position"`
+	Valence  int    `gorm:"not null;default:0" json:"valence"` // 1 — приятное, -1 — неприятное, 0 — нейтральное
+}
@@ -1,10 +1,29 @@
 package models
 
-import "time"
+import (
+	"time"
 
+	"github.com/lib/pq"
+)
+
+// MoodEntry — запись в дневнике настроения; за день их может быть несколько
+type MoodEntry struct {
+	ID        uint           `gorm:"primaryKey" json:"id"`
+	UserID    uint           `gorm:"not null;index" json:"user_id"`
+	Date      time.Time      `gorm:"type:date;not null" json:"date"` // День, к которому относится запись
+	LoggedAt  time.Time      `gorm:"not null" json:"logged_at"`
+	Mood      string         `gorm:"type:varchar(64);not null" json:"mood"`
+	Intensity int            `gorm:"not null;default:3" json:"intensity"` // Сила от 1 до 5
+	Note      string         `json:"note"`
+	Tags      pq.StringArray `gorm:"type:varchar(32)[]" json:"tags"`
+}
+
+// MoodStat — настроение за день: последняя запись дня и сводка по остальным
 type MoodStat struct {
-	ID     uint      `gorm:"primaryKey" json:"id"`
-	UserID uint      `gorm:"not null;index" json:"user_id"`
-	Date   time.Time `gorm:"type:date;not null;index" json:"date"`
-	Mood   string    `gorm:"type:varchar(64);not null" json:"mood"`
+	ID        uint      `json:"id"` // id последней записи дня
+	UserID    uint      `json:"user_id"`
+	Date      time.Time `json:"date"`
+	Mood      string    `json:"mood"`
+	Intensity float64   `json:"intensity"` // Средняя сила за день
+	Entries   int       `json:"entries"`   // Сколько записей за день
 }
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Review — оценка активности пользователем (1–5) с необязательным текстом; один отзыв
+// на пользователя и активность. Скрытые модератором отзывы не показываются и не входят в рейтинг.
+type Review struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_reviews_user_activity,priority:1" json:"user_id"`
+	ActivityID uint      `gorm:"not null;uniqueIndex:idx_reviews_user_activity,priority:2" json:"activity_id"`
+	Rating     int       `gorm:"not null" json:"rating"`
+	Text       string    `gorm:"not null;default:''" json:"text"`
+	Hidden     bool      `gorm:"not null;default:false" json:"hidden"`
+	CreatedAt  time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	Username   string    `gorm:"->" json:"username"` // Автор, только при чтении
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// SearchEvent — что пользователь искал в списке активностей. Отдельно от
+// mood_stats: поиск по настроению — это намерение, а не отметка настроения.
+type SearchEvent struct {
+	ID          uint           `gorm:"primaryKey" json:"id"`
+	UserID      uint           `gorm:"not null;index" json:"user_id"`
+	Query       string         `gorm:"size:256" json:"query"` // Текст поиска (q)
+	Moods       pq.StringArray `gorm:"type:varchar(64)[]" json:"moods"`
+	Params      string         `json:"params"` // Все query-параметры запроса как есть
+	ResultCount int64          `gorm:"not null" json:"result_count"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Session — сессия входа. Все refresh-токены, выпущенные в рамках одного логина,
+// принадлежат одной сессии (семейству), и отзыв сессии отзывает их все.
+type Session struct {
+	ID        string     `gorm:"primaryKey;size:32" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// RefreshToken хранится только в виде хеша; сам токен отдаётся клиенту один раз
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	SessionID string     `gorm:"not null;index;size:32" json:"session_id"`
+	UserID    uint       `gorm:"not null" json:"user_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
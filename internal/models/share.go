@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Share — публичная ссылка на коллекцию или активность: открывается по Slug без авторизации,
+// пока не истекла и владелец её не отозвал
+type Share struct {
+	ID           uint       `gorm:"primaryKey" json:"-"`
+	Slug         string     `gorm:"uniqueIndex;size:32;not null" json:"slug"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	CollectionID *uint      `json:"collection_id,omitempty"` // Задано ровно одно из CollectionID и ActivityID
+	ActivityID   *uint      `json:"activity_id,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at"` // nil — бессрочно
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ViewCount    int        `gorm:"not null;default:0" json:"view_count"`
+	CreatedAt    time.Time  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// Active — открывается ли ссылка в момент now
+func (s Share) Active(now time.Time) bool {
+	return s.RevokedAt == nil && (s.ExpiresAt == nil || now.Before(*s.ExpiresAt))
+}
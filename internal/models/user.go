@@ -9,5 +9,7 @@ type User struct {
 	Username     string    `gorm:"unique;not null;size:64" json:"username"`
 	PasswordHash string    `gorm:"not null;size:128" json:"-"`
 	Role         string    `gorm:"type:varchar(16);default:user" json:"role"`
+	City         string    `gorm:"size:128" json:"city"`    // Для погоды по weather=auto
+	Timezone     string    `gorm:"size:64" json:"timezone"` // IANA, например Europe/Moscow; пусто — часовой пояс сервера по умолчанию
 	CreatedAt    time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
@@ -0,0 +1,94 @@
+// Package moods проверяет настроения по словарю.
+//
+// Клиенты могут передавать slug настроения или его подпись на любом языке
+// в любом регистре и с лишними пробелами — всё приводится к slug.
+package moods
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+var ErrUnknown = errors.New("unknown mood")
+
+var (
+	slugPattern  = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+	colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+// Dictionary — словарь настроений для проверки и подписей
+type Dictionary struct {
+	labels  map[string]string // slug -> подпись по-русски
+	valence map[string]int    // slug -> окраска
+	keys    map[string]string // slug и подписи в нижнем регистре -> slug
+}
+
+func NewDictionary(list []models.Mood) Dictionary {
+	d := Dictionary{
+		labels:  make(map[string]string, len(list)),
+		valence: make(map[string]int, len(list)),
+		keys:    make(map[string]string, len(list)*3),
+	}
+	for _, m := range list {
+		d.labels[m.Slug] = m.LabelRu
+		d.valence[m.Slug] = m.Valence
+		for _, k := range []string{m.Slug, m.LabelRu, m.LabelEn} {
+			if k = key(k); k != "" {
+				d.keys[k] = m.Slug
+			}
+		}
+	}
+	return d
+}
+
+func key(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Normalize возвращает slug настроения по slug или подписи
+func (d Dictionary) Normalize(s string) (string, bool) {
+	slug, ok := d.keys[key(s)]
+	return slug, ok
+}
+
+// NormalizeAll приводит список к slug'ам без повторов, сохраняя порядок;
+// пустые значения пропускаются, неизвестное — ErrUnknown
+func (d Dictionary) NormalizeAll(values []string) ([]string, error) {
+	result := []string{}
+	seen := make(map[string]bool, len(values))
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		slug, ok := d.Normalize(v)
+		if !ok {
+			return nil, ErrUnknown
+		}
+		if !seen[slug] {
+			seen[slug] = true
+			result = append(result, slug)
+		}
+	}
+	return result, nil
+}
+
+// Labels — подписи по-русски для slug'ов
+func (d Dictionary) Labels() map[string]string {
+	return d.labels
+}
+
+// Valence — окраска настроений по slug'ам
+func (d Dictionary) Valence() map[string]int {
+	return d.valence
+}
+
+// Validate проверяет новую или изменённую запись словаря
+func Validate(m models.Mood) bool {
+	return slugPattern.MatchString(m.Slug) &&
+		strings.TrimSpace(m.LabelRu) != "" && strings.TrimSpace(m.LabelEn) != "" &&
+		(m.Color == "" || colorPattern.MatchString(m.Color)) &&
+		m.Valence >= -1 && m.Valence <= 1
+}
@@ -0,0 +1,271 @@
+// Package moodstats считает сводку по дневнику настроения: распределение
+// по неделям или месяцам, самое частое настроение, серии дней с записями
+// и тренд приятных и неприятных настроений.
+//
+// Считается всё по записям дневника (несколько записей за день учитываются
+// каждая), а серии — по всем дням, в которые была хотя бы одна запись. Функции
+// чистые: «сегодня» передаётся в Options, поэтому результат зависит только
+// от входных данных.
+package moodstats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+const (
+	Week  = "week"
+	Month = "month"
+
+	TrendUp   = "up"
+	TrendDown = "down"
+	TrendFlat = "flat"
+	TrendNone = "none" // не с чем сравнить: в одном из окон нет записей
+
+	// trendThreshold — насколько должен измениться балл, чтобы тренд не считался ровным
+	trendThreshold = 0.1
+)
+
+type Options struct {
+	Today     time.Time // Текущий день (полночь)
+	From      time.Time // С какого дня считать распределение и самое частое настроение
+	Period    string    // Week или Month
+	TrendDays int       // Длина окна тренда в днях
+}
+
+type Count struct {
+	Mood  string `json:"mood"`
+	Count int    `json:"count"`
+}
+
+// Bucket — записи за одну неделю (с понедельника) или месяц
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Total int       `json:"total"`
+	Moods []Count   `json:"moods"` // По убыванию числа записей
+}
+
+type Streaks struct {
+	Current    int        `json:"current"` // Дней подряд по сегодня; если сегодня записи ещё нет — по вчера
+	Longest    int        `json:"longest"`
+	LastLogged *time.Time `json:"last_logged"`
+}
+
+// Trend сравнивает последние TrendDays дней с предыдущими столькими же
+type Trend struct {
+	Days          int      `json:"days"`
+	Positive      int      `json:"positive"`
+	Neutral       int      `json:"neutral"`
+	Negative      int      `json:"negative"`
+	Score         float64  `json:"score"`          // (приятные - неприятные) / все, от -1 до 1
+	PreviousScore *float64 `json:"previous_score"` // То же за предыдущее окно; nil, если записей не было
+	Direction     string   `json:"direction"`
+}
+
+type Summary struct {
+	Period       string    `json:"period"`
+	From         time.Time `json:"from"`
+	Total        int       `json:"total"`
+	TopMood      *Count    `json:"top_mood"`
+	Distribution []Bucket  `json:"distribution"`
+	Streaks      Streaks   `json:"streaks"`
+	Trend        Trend     `json:"trend"`
+}
+
+// Summarize собирает сводку. entries должны покрывать и период распределения,
+// и оба окна тренда; logged — все дни с записями за всё время, по ним считаются
+// серии (повторы и порядок не важны); valence — окраска настроений по slug (1, 0 или -1).
+func Summarize(entries []models.MoodEntry, logged []time.Time, valence map[string]int, opts Options) Summary {
+	today := day(opts.Today)
+	from := day(opts.From)
+	s := Summary{Period: opts.Period, From: from, Distribution: []Bucket{}}
+
+	total := make(map[string]int)
+	buckets := make(map[time.Time]map[string]int)
+	for _, e := range entries {
+		d := day(e.Date)
+		if d.Before(from) || d.After(today) {
+			continue
+		}
+		s.Total++
+		total[e.Mood]++
+		start := bucketStart(d, opts.Period)
+		if buckets[start] == nil {
+			buckets[start] = make(map[string]int)
+		}
+		buckets[start][e.Mood]++
+	}
+	if top := counts(total); len(top) > 0 {
+		s.TopMood = &top[0]
+	}
+	for start := bucketStart(from, opts.Period); !start.After(today); start = next(start, opts.Period) {
+		b := Bucket{Start: start, Moods: counts(buckets[start])}
+		for _, c := range b.Moods {
+			b.Total += c.Count
+		}
+		s.Distribution = append(s.Distribution, b)
+	}
+
+	s.Streaks = streaks(logged, today)
+	s.Trend = trend(entries, valence, today, opts.TrendDays)
+	return s
+}
+
+// counts — настроения по убыванию числа записей, при равенстве по slug
+func counts(m map[string]int) []Count {
+	result := make([]Count, 0, len(m))
+	for mood, n := range m {
+		result = append(result, Count{Mood: mood, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Mood < result[j].Mood
+	})
+	return result
+}
+
+func streaks(loggedDays []time.Time, today time.Time) Streaks {
+	logged := make(map[time.Time]bool)
+	var days []time.Time
+	for _, d := range loggedDays {
+		d = day(d)
+		if d.After(today) || logged[d] {
+			continue
+		}
+		logged[d] = true
+		days = append(days, d)
+	}
+	var s Streaks
+	if len(days) == 0 {
+		return s
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	last := days[len(days)-1]
+	s.LastLogged = &last
+
+	run := 0
+	for i, d := range days {
+		if i > 0 && days[i-1].AddDate(0, 0, 1).Equal(d) {
+			run++
+		} else {
+			run = 1
+		}
+		s.Longest = max(s.Longest, run)
+	}
+	// Серия не прерывается, пока сегодняшняя запись ещё не сделана
+	d := today
+	if !logged[d] {
+		d = d.AddDate(0, 0, -1)
+	}
+	for logged[d] {
+		s.Current++
+		d = d.AddDate(0, 0, -1)
+	}
+	return s
+}
+
+func trend(entries []models.MoodEntry, valence map[string]int, today time.Time, days int) Trend {
+	t := Trend{Days: days, Direction: TrendNone}
+	if days < 1 {
+		return t
+	}
+	start := today.AddDate(0, 0, -days+1)
+	prevStart := start.AddDate(0, 0, -days)
+	var prevPositive, prevNegative, prevTotal int
+	for _, e := range entries {
+		d := day(e.Date)
+		v := valence[e.Mood]
+		switch {
+		case d.After(today) || d.Before(prevStart):
+		case d.Before(start):
+			prevTotal++
+			if v > 0 {
+				prevPositive++
+			} else if v < 0 {
+				prevNegative++
+			}
+		case v > 0:
+			t.Positive++
+		case v < 0:
+			t.Negative++
+		default:
+			t.Neutral++
+		}
+	}
+	current := t.Positive + t.Neutral + t.Negative
+	if current > 0 {
+		t.Score = score(t.Positive, t.Negative, current)
+	}
+	if prevTotal == 0 {
+		return t
+	}
+	prev := score(prevPositive, prevNegative, prevTotal)
+	t.PreviousScore = &prev
+	if current == 0 {
+		return t
+	}
+	switch change := t.Score - prev; {
+	case change > trendThreshold:
+		t.Direction = TrendUp
+	case change < -trendThreshold:
+		t.Direction = TrendDown
+	default:
+		t.Direction = TrendFlat
+	}
+	return t
+}
+
+func score(positive, negative, total int) float64 {
+	return math.Round(float64(positive-negative)/float64(total)*100) / 100
+}
+
+// day — полночь того же календарного дня
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func bucketStart(d time.Time, period string) time.Time {
+	if period == Month {
+		return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return d.AddDate(0, 0, -(int(d.Weekday())+6)%7)
+}
+
+func next(start time.Time, period string) time.Time {
+	if period == Month {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// CalendarDay — день для календаря настроения; у дней без записей Mood — nil
+type CalendarDay struct {
+	Date      time.Time `json:"date"`
+	Mood      *string   `json:"mood"`
+	Intensity float64   `json:"intensity"`
+	Entries   int       `json:"entries"`
+}
+
+// Calendar раскладывает настроение по дням на все дни from..to по порядку,
+// дни без записей остаются пустыми
+func Calendar(stats []models.MoodStat, from, to time.Time) []CalendarDay {
+	byDay := make(map[time.Time]models.MoodStat, len(stats))
+	for _, s := range stats {
+		byDay[day(s.Date)] = s
+	}
+	days := []CalendarDay{}
+	for d := day(from); !d.After(day(to)); d = d.AddDate(0, 0, 1) {
+		cd := CalendarDay{Date: d}
+		if s, ok := byDay[d]; ok {
+			mood := s.Mood
+			cd.Mood, cd.Intensity, cd.Entries = &mood, s.Intensity, s.Entries
+		}
+		days = append(days, cd)
+	}
+	return days
+}
@@ -0,0 +1,243 @@
+package moodstats
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// today — четверг
+var today = date(2025, 7, 10)
+
+var valence = map[string]int{"cheerful": 1, "calm": 0, "sad": -1}
+
+func date(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func entry(d time.Time, mood string) models.MoodEntry {
+	return models.MoodEntry{Date: d, LoggedAt: d.Add(9 * time.Hour), Mood: mood}
+}
+
+// daysAgo — записи с настроением mood за каждый из указанных дней до today
+func daysAgo(mood string, days ...int) []models.MoodEntry {
+	var result []models.MoodEntry
+	for _, n := range days {
+		result = append(result, entry(today.AddDate(0, 0, -n), mood))
+	}
+	return result
+}
+
+func TestSummarizeDistribution(t *testing.T) {
+	entries := []models.MoodEntry{
+		entry(date(2025, 6, 24), "calm"), // раньше from
+		entry(date(2025, 6, 25), "calm"),
+		entry(date(2025, 6, 29), "cheerful"), // воскресенье
+		entry(date(2025, 6, 30), "calm"),     // понедельник
+		entry(date(2025, 7, 6), "sad"),
+		entry(date(2025, 7, 7), "cheerful"),
+		entry(date(2025, 7, 10), "calm"),
+		entry(date(2025, 7, 11), "calm"), // позже today
+	}
+	tests := []struct {
+		period string
+		want   []Bucket
+	}{
+		{Week, []Bucket{
+			{Start: date(2025, 6, 23), Total: 2, Moods: []Count{{"calm", 1}, {"cheerful", 1}}},
+			{Start: date(2025, 6, 30), Total: 2, Moods: []Count{{"calm", 1}, {"sad", 1}}},
+			{Start: date(2025, 7, 7), Total: 2, Moods: []Count{{"calm", 1}, {"cheerful", 1}}},
+		}},
+		{Month, []Bucket{
+			{Start: date(2025, 6, 1), Total: 3, Moods: []Count{{"calm", 2}, {"cheerful", 1}}},
+			{Start: date(2025, 7, 1), Total: 3, Moods: []Count{{"calm", 1}, {"cheerful", 1}, {"sad", 1}}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			s := Summarize(entries, nil, valence, Options{Today: today, From: date(2025, 6, 25), Period: tt.period, TrendDays: 7})
+			if s.Total != 6 {
+				t.Errorf("total = %d, want 6", s.Total)
+			}
+			if s.TopMood == nil || *s.TopMood != (Count{"calm", 3}) {
+				t.Errorf("top mood = %v, want calm ×3", s.TopMood)
+			}
+			if !reflect.DeepEqual(s.Distribution, tt.want) {
+				t.Errorf("distribution = %+v, want %+v", s.Distribution, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummarizeEmptyWeeks(t *testing.T) {
+	s := Summarize(nil, nil, valence, Options{Today: today, From: date(2025, 6, 30), Period: Week, TrendDays: 7})
+	want := []Bucket{
+		{Start: date(2025, 6, 30), Moods: []Count{}},
+		{Start: date(2025, 7, 7), Moods: []Count{}},
+	}
+	if s.Total != 0 || s.TopMood != nil || !reflect.DeepEqual(s.Distribution, want) {
+		t.Errorf("summary = %+v", s)
+	}
+}
+
+func TestTopMoodTieBreak(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []models.MoodEntry
+		want    Count
+	}{
+		{"most frequent", append(daysAgo("sad", 1, 2, 3), daysAgo("calm", 1, 2)...), Count{"sad", 3}},
+		{"tie by slug", append(daysAgo("sad", 1, 2), daysAgo("cheerful", 3, 4)...), Count{"cheerful", 2}},
+		{"several entries a day count each", append(daysAgo("sad", 1, 1, 1), daysAgo("calm", 2, 3)...), Count{"sad", 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Summarize(tt.entries, nil, valence, Options{Today: today, From: today.AddDate(0, 0, -30), Period: Week, TrendDays: 7})
+			if s.TopMood == nil || *s.TopMood != tt.want {
+				t.Errorf("top mood = %v, want %v", s.TopMood, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreaks(t *testing.T) {
+	last := func(n int) *time.Time {
+		d := today.AddDate(0, 0, -n)
+		return &d
+	}
+	// logged — дни с записями, n дней назад
+	logged := func(days ...int) []time.Time {
+		result := make([]time.Time, len(days))
+		for i, n := range days {
+			result[i] = today.AddDate(0, 0, -n)
+		}
+		return result
+	}
+	var year []int
+	for n := 1; n <= 400; n++ {
+		year = append(year, n)
+	}
+	tests := []struct {
+		name   string
+		logged []time.Time
+		want   Streaks
+	}{
+		{"no entries", nil, Streaks{}},
+		{"including today", logged(0, 1, 2), Streaks{Current: 3, Longest: 3, LastLogged: last(0)}},
+		{"today not logged yet", logged(1, 2), Streaks{Current: 2, Longest: 2, LastLogged: last(1)}},
+		{"only today", logged(0, 2, 3), Streaks{Current: 1, Longest: 2, LastLogged: last(0)}},
+		{"broken two days ago", logged(2, 3, 4), Streaks{Current: 0, Longest: 3, LastLogged: last(2)}},
+		{"longest in the past", logged(0, 1, 20, 21, 22, 23, 24), Streaks{Current: 2, Longest: 5, LastLogged: last(0)}},
+		{"repeated days", logged(1, 1, 2, 2, 2), Streaks{Current: 2, Longest: 2, LastLogged: last(1)}},
+		{"time of day ignored", append(logged(1), today.Add(15*time.Hour)), Streaks{Current: 2, Longest: 2, LastLogged: last(0)}},
+		{"future days ignored", logged(-1, 1), Streaks{Current: 1, Longest: 1, LastLogged: last(1)}},
+		{"unsorted", logged(3, 0, 2, 1), Streaks{Current: 4, Longest: 4, LastLogged: last(0)}},
+		{"longer than a year", logged(year...), Streaks{Current: 400, Longest: 400, LastLogged: last(1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(nil, tt.logged, valence, Options{Today: today, From: today, Period: Week, TrendDays: 7}).Streaks
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("streaks = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrend(t *testing.T) {
+	// Окно — 7 дней: текущее с 0 по 6 день назад, предыдущее с 7 по 13
+	prev := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name    string
+		entries []models.MoodEntry
+		days    int
+		want    Trend
+	}{
+		{
+			name:    "up",
+			entries: slices.Concat(daysAgo("sad", 8, 9), daysAgo("cheerful", 0, 1), daysAgo("calm", 3)),
+			days:    7,
+			want:    Trend{Days: 7, Positive: 2, Neutral: 1, Score: 0.67, PreviousScore: prev(-1), Direction: TrendUp},
+		},
+		{
+			name:    "down",
+			entries: slices.Concat(daysAgo("cheerful", 7, 13), daysAgo("sad", 2), daysAgo("calm", 6)),
+			days:    7,
+			want:    Trend{Days: 7, Neutral: 1, Negative: 1, Score: -0.5, PreviousScore: prev(1), Direction: TrendDown},
+		},
+		{
+			name:    "flat within threshold",
+			entries: slices.Concat(daysAgo("cheerful", 7, 8), daysAgo("calm", 9, 10), daysAgo("cheerful", 0, 1, 2), daysAgo("calm", 3, 4, 5, 6)),
+			days:    7,
+			want:    Trend{Days: 7, Positive: 3, Neutral: 4, Score: 0.43, PreviousScore: prev(0.5), Direction: TrendFlat},
+		},
+		{
+			name:    "none without previous window",
+			entries: slices.Concat(daysAgo("cheerful", 0), daysAgo("sad", 14)),
+			days:    7,
+			want:    Trend{Days: 7, Positive: 1, Score: 1, Direction: TrendNone},
+		},
+		{
+			name:    "none without current window",
+			entries: daysAgo("sad", 7),
+			days:    7,
+			want:    Trend{Days: 7, PreviousScore: prev(-1), Direction: TrendNone},
+		},
+		{
+			name:    "future entries ignored",
+			entries: slices.Concat(daysAgo("sad", -1), daysAgo("calm", 0, 7)),
+			days:    7,
+			want:    Trend{Days: 7, Neutral: 1, PreviousScore: prev(0), Direction: TrendFlat},
+		},
+		{
+			name:    "no window",
+			entries: daysAgo("cheerful", 0, 1),
+			days:    0,
+			want:    Trend{Direction: TrendNone},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Summarize(tt.entries, nil, valence, Options{Today: today, From: today, Period: Week, TrendDays: tt.days}).Trend
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trend = %+v (previous %v), want %+v (previous %v)", got, deref(got.PreviousScore), tt.want, deref(tt.want.PreviousScore))
+			}
+		})
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	stats := []models.MoodStat{
+		{Date: date(2025, 6, 30), Mood: "sad", Intensity: 2, Entries: 1}, // раньше from
+		{Date: date(2025, 7, 2).Add(15 * time.Hour), Mood: "calm", Intensity: 3.5, Entries: 2},
+		{Date: date(2025, 7, 4), Mood: "cheerful", Intensity: 5, Entries: 1},
+	}
+	calm, cheerful := "calm", "cheerful"
+	want := []CalendarDay{
+		{Date: date(2025, 7, 1)},
+		{Date: date(2025, 7, 2), Mood: &calm, Intensity: 3.5, Entries: 2},
+		{Date: date(2025, 7, 3)},
+		{Date: date(2025, 7, 4), Mood: &cheerful, Intensity: 5, Entries: 1},
+		{Date: date(2025, 7, 5)},
+	}
+	if got := Calendar(stats, date(2025, 7, 1), date(2025, 7, 5)); !reflect.DeepEqual(got, want) {
+		t.Errorf("calendar = %+v, want %+v", got, want)
+	}
+
+	if got := Calendar(stats, date(2025, 7, 4), date(2025, 7, 4)); len(got) != 1 || got[0].Mood == nil || *got[0].Mood != "cheerful" {
+		t.Errorf("single day = %+v", got)
+	}
+	if got := Calendar(stats, date(2025, 7, 5), date(2025, 7, 1)); got == nil || len(got) != 0 {
+		t.Errorf("from after to = %#v, want an empty list", got)
+	}
+}
+
+func deref(f *float64) any {
+	if f == nil {
+		return nil
+	}
+	return *f
+}
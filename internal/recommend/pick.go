@@ -0,0 +1,46 @@
+package recommend
+
+import "math/rand/v2"
+
+// minPickWeight — наименьший вес при случайном выборе
+const minPickWeight = 0.1
+
+// Pick случайно выбирает одну рекомендацию с вероятностью, пропорциональной
+// баллу +1. Балл бывает отрицательным (после выполнения стало хуже), поэтому
+// вес не опускается ниже minPickWeight: шанс есть у каждой активности.
+//
+// recent — id последних выборов, от новых к старым; они не повторяются.
+// Если без них выбирать не из чего, ограничение ослабляется: первым
+// «прощается» самый давний выбор, самый свежий — последним.
+func Pick(recs []Recommendation, recent []uint, rng *rand.Rand) (Recommendation, bool) {
+	for n := len(recent); n >= 0; n-- {
+		excluded := make(map[uint]bool, n)
+		for _, id := range recent[:n] {
+			excluded[id] = true
+		}
+		var pool []Recommendation
+		var total float64
+		for _, r := range recs {
+			if !excluded[r.Activity.ID] {
+				pool = append(pool, r)
+				total += weight(r)
+			}
+		}
+		if len(pool) == 0 {
+			continue
+		}
+		x := rng.Float64() * total
+		for _, r := range pool {
+			x -= weight(r)
+			if x < 0 {
+				return r, true
+			}
+		}
+		return pool[len(pool)-1], true
+	}
+	return Recommendation{}, false
+}
+
+func weight(r Recommendation) float64 {
+	return max(r.Score+1, minPickWeight)
+}
@@ -0,0 +1,105 @@
+package recommend
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+func recs(scores ...float64) []Recommendation {
+	result := make([]Recommendation, len(scores))
+	for i, s := range scores {
+		result[i] = Recommendation{Activity: models.Activity{ID: uint(i + 1)}, Score: s}
+	}
+	return result
+}
+
+func seeded(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+func TestPickSameSeed(t *testing.T) {
+	candidates := recs(0, 1, 2, 3, 4, 5, 6, 7)
+	for seed := uint64(0); seed < 20; seed++ {
+		first, ok := Pick(candidates, []uint{2}, seeded(seed))
+		if !ok {
+			t.Fatalf("seed %d: nothing picked", seed)
+		}
+		again, _ := Pick(candidates, []uint{2}, seeded(seed))
+		if first.Activity.ID != again.Activity.ID {
+			t.Errorf("seed %d: picked %d, then %d", seed, first.Activity.ID, again.Activity.ID)
+		}
+	}
+}
+
+func TestPickWeights(t *testing.T) {
+	// У 2 вес 10, у 1 — 1: за 1100 выборов 2 должно выпасть заметно чаще
+	candidates := recs(0, 9)
+	rng := seeded(1)
+	counts := make(map[uint]int)
+	for i := 0; i < 1100; i++ {
+		r, _ := Pick(candidates, nil, rng)
+		counts[r.Activity.ID]++
+	}
+	if counts[1] == 0 || counts[2] < 8*counts[1] {
+		t.Errorf("counts = %v, want about 100 and 1000", counts)
+	}
+}
+
+func TestPickNegativeScores(t *testing.T) {
+	// Без нижней границы веса -2 и -5 давали бы отрицательный вес и не выпадали никогда
+	candidates := recs(-2, -5, 0, -0.5)
+	rng := seeded(1)
+	counts := make(map[uint]int)
+	for i := 0; i < 2000; i++ {
+		r, ok := Pick(candidates, nil, rng)
+		if !ok {
+			t.Fatal("nothing picked")
+		}
+		counts[r.Activity.ID]++
+	}
+	for _, c := range candidates {
+		if counts[c.Activity.ID] == 0 {
+			t.Errorf("activity %d with score %v is never picked: %v", c.Activity.ID, c.Score, counts)
+		}
+	}
+	if counts[3] < counts[1] {
+		t.Errorf("counts = %v: score 0 should win over -2", counts)
+	}
+}
+
+func TestPickRecent(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []Recommendation
+		recent     []uint // от новых к старым
+		want       []uint // допустимые результаты
+	}{
+		{"recent are excluded", recs(0, 0, 0, 0, 0, 0, 0), []uint{1, 2, 3, 4, 5}, []uint{6, 7}},
+		{"only one is left", recs(5, 5, 5, 5, 5, 0), []uint{5, 4, 3, 2, 1}, []uint{6}},
+		{"all recent: oldest is forgiven first", recs(0, 0, 0), []uint{3, 2, 1}, []uint{1}},
+		{"all recent: then the next oldest", recs(0, 0), []uint{1, 2}, []uint{2}},
+		{"recent not among candidates", recs(0, 0), []uint{7, 8, 9}, []uint{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for seed := uint64(0); seed < 20; seed++ {
+				r, ok := Pick(tt.candidates, tt.recent, seeded(seed))
+				if !ok {
+					t.Fatalf("seed %d: nothing picked", seed)
+				}
+				if !slices.Contains(tt.want, r.Activity.ID) {
+					t.Fatalf("seed %d: picked %d, want one of %v", seed, r.Activity.ID, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestPickEmpty(t *testing.T) {
+	if _, ok := Pick(nil, []uint{1}, seeded(1)); ok {
+		t.Error("picked from no candidates")
+	}
+}
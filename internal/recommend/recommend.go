@@ -0,0 +1,286 @@
+// Package recommend подбирает активности для пользователя.
+//
+// Скоринг — чистая функция от кандидатов, профиля пользователя (избранное,
+// история, недавние настроения, выполненные активности) и условий запроса: всё время берётся из
+// Options.Now, а при равных баллах порядок определяется id, поэтому при
+// одинаковых входных данных результат всегда одинаковый.
+package recommend
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/zenrush/backend/internal/insights"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+const (
+	weightMood      = 2.0 // за каждое совпадение с недавним настроением
+	weightFavorites = 1.5 // за похожесть на избранное
+	weightHistory   = 0.5 // за похожесть на то, что пользователь смотрел
+	bonusFavorite   = 0.5 // активность уже в избранном
+	bonusViewed     = 0.3 // за каждый старый просмотр, не больше maxViewedBonus
+	maxViewedBonus  = 0.9
+	weightBoost     = 2.0 // за то, как активность меняла настроение раньше (от -1 до 1)
+	weightMoodBoost = 1.0 // за то, как меняли настроение похожие активности
+
+	explicitMoodWeight = 2.0                // вес настроения, указанного в запросе
+	searchMoodWeight   = 0.5                // вес настроения из недавнего поиска относительно отметки
+	moodHalfLife       = 3 * 24 * time.Hour // за сколько вклад отмеченного настроения падает вдвое
+)
+
+// Context — условия запроса: что пользователь может себе позволить прямо сейчас.
+// Незаданные поля не ограничивают подбор.
+type Context struct {
+	Budget      *int     // Сколько готов потратить, ₽
+	Hours       *int     // Сколько есть времени, часов
+	Weather     []string // Погода за окном; подходит активность хотя бы для одного условия
+	PeopleCount *int     // Размер компании
+	Moods       []string // Настроения, указанные явно
+}
+
+// Profile — что известно о пользователе
+type Profile struct {
+	Favorites []models.Activity
+	History   []models.History     // Просмотры, порядок не важен
+	Moods     []models.MoodStat    // Недавние отметки настроения
+	Searches  []models.SearchEvent // Недавние поиски: настроения из них весят меньше отметок
+	// Выполненные активности с настроением до и после; нужна Options.MoodValence
+	Completions []models.ActivityCompletion
+}
+
+type Options struct {
+	Now time.Time
+	// ExcludeViewedWithin — активности, просмотренные за это время, не предлагаются
+	ExcludeViewedWithin time.Duration
+	Limit               int // <= 0 — без ограничения
+	// MoodLabels — подписи настроений для текстов причин; без подписи выводится slug
+	MoodLabels map[string]string
+	// MoodValence — окраска настроений для оценки выполненных активностей
+	MoodValence map[string]int
+}
+
+// Reason объясняет, почему активность попала в подборку
+type Reason struct {
+	Code string `json:"code"`
+	Text string `json:"text"`
+}
+
+type Recommendation struct {
+	Activity models.Activity `json:"activity"`
+	Score    float64         `json:"score"`
+	Reasons  []Reason        `json:"reasons"`
+}
+
+// Recommend отбрасывает неподходящих кандидатов, оценивает остальных и
+// возвращает их по убыванию балла
+func Recommend(candidates []models.Activity, p Profile, c Context, opts Options) []Recommendation {
+	moods := moodAffinity(p.Moods, p.Searches, c.Moods, opts.Now)
+	favoriteTags := tagAffinity(p.Favorites)
+	favoriteIDs := make(map[uint]bool, len(p.Favorites))
+	for _, f := range p.Favorites {
+		favoriteIDs[f.ID] = true
+	}
+	recent := make(map[uint]bool)
+	views := make(map[uint]int)
+	for _, h := range p.History {
+		if opts.ExcludeViewedWithin > 0 && opts.Now.Sub(h.ViewedAt) < opts.ExcludeViewedWithin {
+			recent[h.ActivityID] = true
+		} else {
+			views[h.ActivityID]++
+		}
+	}
+	var viewed []models.Activity
+	for _, a := range candidates {
+		if views[a.ID] > 0 {
+			viewed = append(viewed, a)
+		}
+	}
+	historyTags := tagAffinity(viewed)
+	boosts := insights.ActivityBoosts(p.Completions, opts.MoodValence)
+	moodBoosts := insights.MoodBoosts(p.Completions, opts.MoodValence)
+
+	result := []Recommendation{}
+	for _, a := range candidates {
+		if recent[a.ID] || !fits(a, c) {
+			continue
+		}
+		r := Recommendation{Activity: a, Reasons: []Reason{}}
+
+		if mood, score := bestMood(a, moods); score > 0 {
+			r.Score += weightMood * score
+			r.Reasons = append(r.Reasons, Reason{"mood", fmt.Sprintf("Подходит под настроение «%s»", label(mood, opts.MoodLabels))})
+		}
+		if score := similarity(a, favoriteTags); score > 0 {
+			r.Score += weightFavorites * score
+			if f, ok := closest(a, p.Favorites); ok {
+				r.Reasons = append(r.Reasons, Reason{"similar_to_favorite", fmt.Sprintf("Похоже на «%s» из избранного", f.Name)})
+			}
+		}
+		if favoriteIDs[a.ID] {
+			r.Score += bonusFavorite
+			r.Reasons = append(r.Reasons, Reason{"favorite", "В избранном"})
+		}
+		if score := similarity(a, historyTags); score > 0 {
+			r.Score += weightHistory * score
+		}
+		if n := views[a.ID]; n > 0 {
+			r.Score += math.Min(bonusViewed*float64(n), maxViewedBonus)
+			r.Reasons = append(r.Reasons, Reason{"viewed_before", "Вы уже интересовались этим"})
+		}
+		if boost, ok := boosts[a.ID]; ok {
+			r.Score += weightBoost * boost
+			if boost > 0 {
+				r.Reasons = append(r.Reasons, Reason{"mood_boost", "Раньше поднимало вам настроение"})
+			}
+		}
+		r.Score += weightMoodBoost * similarity(a, moodBoosts)
+		r.Reasons = append(r.Reasons, contextReasons(a, c)...)
+		r.Score = math.Round(r.Score*100) / 100
+		result = append(result, r)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Activity.ID < result[j].Activity.ID
+	})
+	if opts.Limit > 0 && len(result) > opts.Limit {
+		result = result[:opts.Limit]
+	}
+	return result
+}
+
+// fits проверяет жёсткие ограничения: бюджет, время, погоду и размер компании
+func fits(a models.Activity, c Context) bool {
+	if c.Budget != nil && a.Budget > *c.Budget {
+		return false
+	}
+	if c.Hours != nil && a.Time > *c.Hours {
+		return false
+	}
+	if !weather.Matches(a.Weather, c.Weather) {
+		return false
+	}
+	if c.PeopleCount != nil && !a.FitsGroup(*c.PeopleCount) {
+		return false
+	}
+	return true
+}
+
+func contextReasons(a models.Activity, c Context) []Reason {
+	var reasons []Reason
+	if c.Budget != nil {
+		if a.Budget == 0 {
+			reasons = append(reasons, Reason{"budget", "Бесплатно"})
+		} else {
+			reasons = append(reasons, Reason{"budget", fmt.Sprintf("Укладывается в бюджет %d ₽", *c.Budget)})
+		}
+	}
+	if c.Hours != nil {
+		reasons = append(reasons, Reason{"time", fmt.Sprintf("Займёт %d ч из %d", a.Time, *c.Hours)})
+	}
+	if len(c.Weather) > 0 {
+		reasons = append(reasons, Reason{"weather", "Подходит для погоды за окном"})
+	}
+	if c.PeopleCount != nil {
+		reasons = append(reasons, Reason{"people", fmt.Sprintf("Подходит для компании из %d", *c.PeopleCount)})
+	}
+	return reasons
+}
+
+// moodAffinity — вес каждого настроения: свежие отметки весят больше старых,
+// поиски — меньше отметок, явно указанные в запросе — больше всех
+func moodAffinity(stats []models.MoodStat, searches []models.SearchEvent, explicit []string, now time.Time) map[string]float64 {
+	affinity := make(map[string]float64)
+	for _, s := range stats {
+		affinity[s.Mood] += decay(now.Sub(s.Date))
+	}
+	for _, s := range searches {
+		for _, m := range s.Moods {
+			affinity[m] += searchMoodWeight * decay(now.Sub(s.CreatedAt))
+		}
+	}
+	for _, m := range explicit {
+		affinity[m] += explicitMoodWeight
+	}
+	return affinity
+}
+
+// decay — вклад события давностью age: вдвое меньше каждые moodHalfLife
+func decay(age time.Duration) float64 {
+	if age < 0 {
+		age = 0
+	}
+	return math.Pow(0.5, float64(age)/float64(moodHalfLife))
+}
+
+func label(slug string, labels map[string]string) string {
+	if l, ok := labels[slug]; ok {
+		return l
+	}
+	return slug
+}
+
+// bestMood возвращает настроение активности с наибольшим весом и суммарный вес её настроений
+func bestMood(a models.Activity, affinity map[string]float64) (string, float64) {
+	var best string
+	var bestWeight, total float64
+	for _, m := range a.Moods {
+		w := affinity[m]
+		total += w
+		if w > bestWeight || (w == bestWeight && w > 0 && m < best) {
+			best, bestWeight = m, w
+		}
+	}
+	return best, total
+}
+
+// tagAffinity — доля активностей, у которых встречается каждое настроение
+func tagAffinity(activities []models.Activity) map[string]float64 {
+	affinity := make(map[string]float64)
+	for _, a := range activities {
+		for _, m := range a.Moods {
+			affinity[m] += 1 / float64(len(activities))
+		}
+	}
+	return affinity
+}
+
+// similarity — средний вес настроений активности
+func similarity(a models.Activity, affinity map[string]float64) float64 {
+	if len(a.Moods) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, m := range a.Moods {
+		sum += affinity[m]
+	}
+	return sum / float64(len(a.Moods))
+}
+
+// closest находит избранную активность (кроме самой a) с наибольшим числом общих настроений
+func closest(a models.Activity, favorites []models.Activity) (models.Activity, bool) {
+	var best models.Activity
+	bestShared := 0
+	for _, f := range favorites {
+		if f.ID == a.ID {
+			continue
+		}
+		shared := 0
+		for _, m := range f.Moods {
+			if slices.Contains(a.Moods, m) {
+				shared++
+			}
+		}
+		if shared > bestShared || (shared == bestShared && shared > 0 && f.ID < best.ID) {
+			best, bestShared = f, shared
+		}
+	}
+	return best, bestShared > 0
+}
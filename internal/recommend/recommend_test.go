@@ -0,0 +1,295 @@
+package recommend
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+var now = time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+
+func ptr(v int) *int {
+	return &v
+}
+
+func ids(recs []Recommendation) []uint {
+	result := make([]uint, len(recs))
+	for i, r := range recs {
+		result[i] = r.Activity.ID
+	}
+	return result
+}
+
+func codes(r Recommendation) []string {
+	result := make([]string, len(r.Reasons))
+	for i, reason := range r.Reasons {
+		result[i] = reason.Code
+	}
+	return result
+}
+
+func byID(recs []Recommendation, id uint) (Recommendation, bool) {
+	for _, r := range recs {
+		if r.Activity.ID == id {
+			return r, true
+		}
+	}
+	return Recommendation{}, false
+}
+
+func TestRecommendFilters(t *testing.T) {
+	candidates := []models.Activity{
+		{ID: 1, Budget: 0, Time: 1, Weather: []string{"any"}, MinPeople: 1},
+		{ID: 2, Budget: 1000, Time: 2, Weather: []string{"any"}, MinPeople: 1},
+		{ID: 3, Budget: 300, Time: 5, Weather: []string{"any"}, MinPeople: 1},
+		{ID: 4, Budget: 300, Time: 2, Weather: []string{"sunny"}, MinPeople: 1},
+		{ID: 5, Budget: 300, Time: 2, Weather: []string{"any"}, MinPeople: 4},
+		{ID: 6, Budget: 300, Time: 2, Weather: []string{"rainy"}, MinPeople: 1, MaxPeople: ptr(2)},
+	}
+	tests := []struct {
+		name string
+		ctx  Context
+		want []uint
+	}{
+		{"no limits", Context{}, []uint{1, 2, 3, 4, 5, 6}},
+		{"budget", Context{Budget: ptr(500)}, []uint{1, 3, 4, 5, 6}},
+		{"budget is inclusive", Context{Budget: ptr(1000)}, []uint{1, 2, 3, 4, 5, 6}},
+		{"hours", Context{Hours: ptr(2)}, []uint{1, 2, 4, 5, 6}},
+		{"weather", Context{Weather: []string{"rainy"}}, []uint{1, 2, 3, 5, 6}},
+		{"any of weather conditions", Context{Weather: []string{"rainy", "sunny"}}, []uint{1, 2, 3, 4, 5, 6}},
+		{"people", Context{PeopleCount: ptr(3)}, []uint{1, 2, 3, 4}},
+		{"all at once", Context{Budget: ptr(500), Hours: ptr(3), Weather: []string{"rainy"}, PeopleCount: ptr(2)}, []uint{1, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ids(Recommend(candidates, Profile{}, tt.ctx, Options{Now: now}))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecommendExcludeViewed(t *testing.T) {
+	candidates := []models.Activity{{ID: 1}, {ID: 2}, {ID: 3}}
+	profile := Profile{History: []models.History{
+		{ActivityID: 1, ViewedAt: now.Add(-time.Hour)},
+		{ActivityID: 2, ViewedAt: now.Add(-48 * time.Hour)},
+		{ActivityID: 2, ViewedAt: now.Add(-72 * time.Hour)},
+	}}
+
+	recs := Recommend(candidates, profile, Context{}, Options{Now: now, ExcludeViewedWithin: 24 * time.Hour})
+	if got := ids(recs); !slices.Equal(got, []uint{2, 3}) {
+		t.Fatalf("got %v, want [2 3]", got)
+	}
+	// Старые просмотры не исключают, а добавляют бонус за каждый
+	if recs[0].Score != 2*bonusViewed || !slices.Equal(codes(recs[0]), []string{"viewed_before"}) {
+		t.Errorf("viewed activity: score %v, reasons %v", recs[0].Score, codes(recs[0]))
+	}
+
+	// Без окна исключения недавний просмотр тоже считается просмотром
+	recs = Recommend(candidates, profile, Context{}, Options{Now: now})
+	if got := ids(recs); !slices.Equal(got, []uint{2, 1, 3}) {
+		t.Errorf("without exclusion: got %v, want [2 1 3]", got)
+	}
+}
+
+func TestRecommendMoodDecay(t *testing.T) {
+	candidates := []models.Activity{
+		{ID: 1, Moods: []string{"calm"}},
+		{ID: 2, Moods: []string{"cheerful"}},
+		{ID: 3, Moods: []string{"sad"}},
+		{ID: 4, Moods: []string{"curious"}},
+	}
+	tests := []struct {
+		name    string
+		profile Profile
+		ctx     Context
+		want    map[uint]float64
+	}{
+		{
+			name: "half life",
+			profile: Profile{Moods: []models.MoodStat{
+				{Mood: "calm", Date: now},
+				{Mood: "cheerful", Date: now.Add(-moodHalfLife)},
+				{Mood: "sad", Date: now.Add(-2 * moodHalfLife)},
+			}},
+			want: map[uint]float64{1: 2, 2: 1, 3: 0.5, 4: 0},
+		},
+		{
+			name: "future entries do not weigh more",
+			profile: Profile{Moods: []models.MoodStat{
+				{Mood: "calm", Date: now.Add(24 * time.Hour)},
+			}},
+			want: map[uint]float64{1: 2, 2: 0, 3: 0, 4: 0},
+		},
+		{
+			name: "searches weigh less than entries",
+			profile: Profile{Searches: []models.SearchEvent{
+				{Moods: []string{"curious"}, CreatedAt: now},
+				{Moods: []string{"cheerful"}, CreatedAt: now.Add(-moodHalfLife)},
+			}},
+			want: map[uint]float64{1: 0, 2: 0.5, 3: 0, 4: 1},
+		},
+		{
+			name: "explicit mood outweighs everything",
+			profile: Profile{Moods: []models.MoodStat{
+				{Mood: "calm", Date: now},
+			}},
+			ctx:  Context{Moods: []string{"sad"}},
+			want: map[uint]float64{1: 2, 2: 0, 3: 4, 4: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := Recommend(candidates, tt.profile, tt.ctx, Options{Now: now})
+			for id, want := range tt.want {
+				r, ok := byID(recs, id)
+				if !ok {
+					t.Fatalf("activity %d is missing", id)
+				}
+				if r.Score != want {
+					t.Errorf("activity %d: score %v, want %v", id, r.Score, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendMoodValence(t *testing.T) {
+	candidates := []models.Activity{
+		{ID: 1, Moods: []string{"calm"}},
+		{ID: 2, Moods: []string{"calm"}},
+		{ID: 3, Moods: []string{"tired"}},
+	}
+	// Прогулка подняла настроение с грусти (-3) до радости (+5): +8 из MaxDelta 10
+	walk := models.ActivityCompletion{ActivityID: 1, MoodBefore: "sad", IntensityBefore: 3, MoodAfter: "happy", IntensityAfter: 5}
+	// После сериала стало хуже: с радости (+2) до грусти (-2)
+	series := models.ActivityCompletion{ActivityID: 3, MoodBefore: "happy", IntensityBefore: 2, MoodAfter: "sad", IntensityAfter: 2}
+	profile := Profile{Completions: []models.ActivityCompletion{walk, series}}
+	valence := map[string]int{"happy": 1, "sad": -1}
+
+	recs := Recommend(candidates, profile, Context{}, Options{Now: now, MoodValence: valence})
+	if got := ids(recs); !slices.Equal(got, []uint{1, 2, 3}) {
+		t.Fatalf("order = %v, want [1 2 3]", got)
+	}
+	want := []struct {
+		score   float64
+		reasons []string
+	}{
+		{weightBoost * 0.8, []string{"mood_boost"}},
+		{0, []string{}},
+		{weightBoost * -0.4, []string{}},
+	}
+	for i, w := range want {
+		if recs[i].Score != w.score || !slices.Equal(codes(recs[i]), w.reasons) {
+			t.Errorf("activity %d: score %v, reasons %v; want %v, %v", recs[i].Activity.ID, recs[i].Score, codes(recs[i]), w.score, w.reasons)
+		}
+	}
+
+	// Похожие по настроению активности тоже получают часть эффекта
+	withActivity := walk
+	withActivity.Activity = &candidates[0]
+	profile = Profile{Completions: []models.ActivityCompletion{withActivity}}
+	recs = Recommend(candidates, profile, Context{}, Options{Now: now, MoodValence: valence})
+	if r, _ := byID(recs, 2); r.Score != weightMoodBoost*0.8 {
+		t.Errorf("similar activity: score %v, want %v", r.Score, weightMoodBoost*0.8)
+	}
+
+	// Без словаря окраски изменение настроения не оценить
+	recs = Recommend(candidates, profile, Context{}, Options{Now: now})
+	for _, r := range recs {
+		if r.Score != 0 {
+			t.Errorf("without valence: activity %d has score %v", r.Activity.ID, r.Score)
+		}
+	}
+}
+
+func TestRecommendReasons(t *testing.T) {
+	favorite := models.Activity{ID: 1, Name: "Прогулка", Moods: []string{"calm"}, Time: 2, Weather: []string{"any"}, MinPeople: 1}
+	candidates := []models.Activity{
+		favorite,
+		{ID: 2, Name: "Чтение", Moods: []string{"calm"}, Budget: 0, Time: 1, Weather: []string{"any"}, MinPeople: 1},
+		{ID: 3, Name: "Кино", Moods: []string{"cheerful"}, Budget: 500, Time: 2, Weather: []string{"any"}, MinPeople: 1},
+	}
+	profile := Profile{
+		Favorites: []models.Activity{favorite},
+		Moods:     []models.MoodStat{{Mood: "calm", Date: now}},
+	}
+	ctx := Context{Budget: ptr(1000), Hours: ptr(3), Weather: []string{"rainy"}, PeopleCount: ptr(2)}
+	recs := Recommend(candidates, profile, ctx, Options{Now: now, MoodLabels: map[string]string{"calm": "Спокойное"}})
+
+	want := map[uint][]Reason{
+		1: {
+			{"mood", "Подходит под настроение «Спокойное»"},
+			{"favorite", "В избранном"},
+			{"budget", "Бесплатно"},
+			{"time", "Займёт 2 ч из 3"},
+			{"weather", "Подходит для погоды за окном"},
+			{"people", "Подходит для компании из 2"},
+		},
+		2: {
+			{"mood", "Подходит под настроение «Спокойное»"},
+			{"similar_to_favorite", "Похоже на «Прогулка» из избранного"},
+			{"budget", "Бесплатно"},
+			{"time", "Займёт 1 ч из 3"},
+			{"weather", "Подходит для погоды за окном"},
+			{"people", "Подходит для компании из 2"},
+		},
+		3: {
+			{"budget", "Укладывается в бюджет 1000 ₽"},
+			{"time", "Займёт 2 ч из 3"},
+			{"weather", "Подходит для погоды за окном"},
+			{"people", "Подходит для компании из 2"},
+		},
+	}
+	for id, reasons := range want {
+		r, ok := byID(recs, id)
+		if !ok {
+			t.Fatalf("activity %d is missing", id)
+		}
+		if !slices.Equal(r.Reasons, reasons) {
+			t.Errorf("activity %d: reasons %v, want %v", id, r.Reasons, reasons)
+		}
+	}
+
+	// Без подписи в тексте причины остаётся slug
+	recs = Recommend(candidates[1:2], profile, Context{}, Options{Now: now})
+	if got := recs[0].Reasons[0].Text; got != "Подходит под настроение «calm»" {
+		t.Errorf("reason without label = %q", got)
+	}
+}
+
+func TestRecommendStableOrder(t *testing.T) {
+	// Кандидаты в обратном порядке; у 4 и 2 балл одинаковый, у 3 и 1 — тоже
+	candidates := []models.Activity{
+		{ID: 5, Moods: []string{"calm"}},
+		{ID: 4, Moods: []string{"cheerful"}},
+		{ID: 3},
+		{ID: 2, Moods: []string{"cheerful"}},
+		{ID: 1},
+	}
+	profile := Profile{Moods: []models.MoodStat{
+		{Mood: "calm", Date: now},
+		{Mood: "cheerful", Date: now.Add(-moodHalfLife)},
+	}}
+	opts := Options{Now: now}
+
+	first := Recommend(candidates, profile, Context{}, opts)
+	if got := ids(first); !slices.Equal(got, []uint{5, 2, 4, 1, 3}) {
+		t.Fatalf("order = %v, want [5 2 4 1 3]", got)
+	}
+	// Порядок не зависит от порядка кандидатов на входе
+	reversed := slices.Clone(candidates)
+	slices.Reverse(reversed)
+	if got := ids(Recommend(reversed, profile, Context{}, opts)); !slices.Equal(got, ids(first)) {
+		t.Errorf("reversed input: order %v, want %v", got, ids(first))
+	}
+
+	opts.Limit = 2
+	if got := ids(Recommend(candidates, profile, Context{}, opts)); !slices.Equal(got, []uint{5, 2}) {
+		t.Errorf("with limit: got %v, want [5 2]", got)
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// ActivityFilter — фильтры списка активностей; nil и пустые строки не применяются
+type ActivityFilter struct {
+	Query       string // Полнотекстовый поиск по названию и описанию
+	MinBudget   *int
+	MaxBudget   *int
+	MinTime     *int // Часов, включительно
+	MaxTime     *int
+	Moods       []string  // slug'и настроений
+	MoodMatch   MoodMatch // Как сочетать несколько настроений; по умолчанию MoodMatchAny
+	Weather     []string  // Подходят активности хотя бы для одного из условий или для любой погоды
+	PeopleCount *int      // Размер компании: подходят активности, в диапазон которых он попадает
+}
+
+// MoodMatch — нужно ли активности хоть одно из настроений фильтра или все сразу
+type MoodMatch string
+
+const (
+	MoodMatchAny MoodMatch = "any"
+	MoodMatchAll MoodMatch = "all"
+)
+
+// ActivitySort — поле сортировки активностей
+type ActivitySort string
+
+const (
+	SortByID         ActivitySort = "id"
+	SortByBudget     ActivitySort = "budget"
+	SortByTime       ActivitySort = "time"
+	SortByCreatedAt  ActivitySort = "created_at"
+	SortByName       ActivitySort = "name"
+	SortByPopularity ActivitySort = "popularity" // Сколько раз активность добавили в избранное
+	SortByRating     ActivitySort = "rating"     // По средней оценке, при равной — по числу отзывов
+	// SortByRelevance — по рангу полнотекстового поиска, имеет смысл только вместе с ActivityFilter.Query
+	SortByRelevance ActivitySort = "relevance"
+	// SortByFavoritedAt — по времени добавления в избранное, только для списка избранного
+	SortByFavoritedAt ActivitySort = "favorited_at"
+)
+
+// ParseActivitySort проверяет значение параметра sort; пустая строка — сортировка по id
+func ParseActivitySort(s string) (ActivitySort, bool) {
+	switch sort := ActivitySort(s); sort {
+	case "":
+		return SortByID, true
+	case SortByID, SortByBudget, SortByTime, SortByCreatedAt, SortByName, SortByPopularity, SortByRating, SortByRelevance, SortByFavoritedAt:
+		return sort, true
+	}
+	return "", false
+}
+
+// ActivityOrder — порядок выдачи; при равенстве значений активности упорядочиваются по id
+type ActivityOrder struct {
+	By   ActivitySort
+	Desc bool
+}
+
+type ActivityRepository interface {
+	// List возвращает страницу активностей и общее число подходящих под фильтр
+	List(ctx context.Context, filter ActivityFilter, order ActivityOrder, page Page) ([]models.Activity, int64, error)
+	Get(ctx context.Context, id uint) (*models.Activity, error)
+	Create(ctx context.Context, activity *models.Activity) error
+	Update(ctx context.Context, activity *models.Activity) error
+	// Delete мягко удаляет активность
+	Delete(ctx context.Context, id uint) error
+	// Count считает все активности, включая удалённые
+	Count(ctx context.Context) (int64, error)
+	// Suggest подбирает до limit названий для автодополнения: сначала совпадения
+	// по началу слов, затем похожие с учётом опечаток
+	Suggest(ctx context.Context, prefix string, limit int) ([]models.ActivitySuggestion, error)
+}
+
+// SearchTerms разбивает поисковую строку на слова в нижнем регистре,
+// отбрасывая всё, кроме букв и цифр
+func SearchTerms(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// CollectionRepository хранит коллекции пользователей. Коллекция по умолчанию —
+// это избранное, с ней же работает FavoriteRepository.
+type CollectionRepository interface {
+	// List возвращает коллекции пользователя с ItemCount, без Items: сначала коллекция
+	// по умолчанию, остальные в порядке создания
+	List(ctx context.Context, userID uint) ([]models.Collection, error)
+	// Get возвращает коллекцию с активностями по порядку (удалённые пропускаются); чужая коллекция — ErrNotFound
+	Get(ctx context.Context, userID, id uint) (*models.Collection, error)
+	Create(ctx context.Context, c *models.Collection) error
+	// Update сохраняет название, описание и обложку; чужая коллекция — ErrNotFound
+	Update(ctx context.Context, c *models.Collection) error
+	// Delete удаляет коллекцию вместе с составом; чужая коллекция — ErrNotFound
+	Delete(ctx context.Context, userID, id uint) error
+	// AddItem добавляет активность в конец коллекции; ErrAlreadyExists, если она уже там
+	AddItem(ctx context.Context, item *models.CollectionItem) error
+	// UpdateItem меняет заметку; ErrNotFound, если активности в коллекции нет
+	UpdateItem(ctx context.Context, item *models.CollectionItem) error
+	RemoveItem(ctx context.Context, collectionID, activityID uint) error
+	// Reorder расставляет активности коллекции в порядке activityIDs
+	Reorder(ctx context.Context, collectionID uint, activityIDs []uint) error
+}
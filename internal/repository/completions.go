@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type CompletionRepository interface {
+	Add(ctx context.Context, completion *models.ActivityCompletion) error
+	// ListSince возвращает выполненные пользователем активности начиная с from,
+	// от новых к старым, вместе с самими активностями
+	ListSince(ctx context.Context, userID uint, from time.Time) ([]models.ActivityCompletion, error)
+	// Each вызывает fn для каждой выполненной активности пользователя, не загружая все сразу
+	Each(ctx context.Context, userID uint, fn func(models.ActivityCompletion) error) error
+}
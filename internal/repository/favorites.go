@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// FavoriteRepository — избранное пользователя, то есть его коллекция по умолчанию;
+// она создаётся при первом добавлении
+type FavoriteRepository interface {
+	// List возвращает страницу избранных активностей пользователя (удалённые пропускаются) и их общее число;
+	// у каждой заполнен FavoritedAt
+	List(ctx context.Context, userID uint, order ActivityOrder, page Page) ([]models.Activity, int64, error)
+	// Add возвращает ErrAlreadyExists, если активность уже в избранном
+	Add(ctx context.Context, userID, activityID uint) error
+	Remove(ctx context.Context, userID, activityID uint) error
+	// Favorited возвращает те из activityIDs, что есть в избранном пользователя
+	Favorited(ctx context.Context, userID uint, activityIDs []uint) ([]uint, error)
+	// Each вызывает fn для каждой избранной активности пользователя, не загружая все сразу
+	Each(ctx context.Context, userID uint, fn func(models.Activity) error) error
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// HistoryFilter ограничивает просмотры по времени: [From, To); нулевое время — без границы
+type HistoryFilter struct {
+	From, To time.Time
+	// Distinct — только последний просмотр каждой активности
+	Distinct bool
+}
+
+type HistoryRepository interface {
+	// List возвращает страницу просмотров пользователя с активностями, от новых к старым,
+	// и их общее число; просмотры удалённых активностей пропускаются
+	List(ctx context.Context, userID uint, filter HistoryFilter, page Page) ([]models.History, int64, error)
+	Add(ctx context.Context, entry *models.History) error
+	// Delete удаляет один просмотр; чужой — ErrNotFound
+	Delete(ctx context.Context, userID, id uint) error
+	// Clear удаляет всю историю пользователя
+	Clear(ctx context.Context, userID uint) error
+	// DeleteBefore удаляет просмотры всех пользователей старше before и возвращает, сколько удалено
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+	// ListSince возвращает просмотры пользователя начиная с from, от новых к старым
+	ListSince(ctx context.Context, userID uint, from time.Time) ([]models.History, error)
+	// Each вызывает fn для каждого просмотра пользователя вместе с активностью, не загружая все сразу
+	Each(ctx context.Context, userID uint, fn func(models.History) error) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type ItineraryRepository interface {
+	// Create сохраняет план вместе с пунктами
+	Create(ctx context.Context, it *models.Itinerary) error
+	// List возвращает планы пользователя от новых к старым и их общее число
+	List(ctx context.Context, userID uint, page Page) ([]models.Itinerary, int64, error)
+	// Get возвращает план с пунктами; чужой план — ErrNotFound
+	Get(ctx context.Context, userID, id uint) (*models.Itinerary, error)
+	Delete(ctx context.Context, userID, id uint) error
+}
@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/weather"
+	"gorm.io/gorm"
+)
+
+type activityRepo struct {
+	d *data
+}
+
+func (r *activityRepo) List(ctx context.Context, f repository.ActivityFilter, order repository.ActivityOrder, page repository.Page) ([]models.Activity, int64, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	terms := repository.SearchTerms(f.Query)
+	if len(terms) == 0 && order.By == repository.SortByRelevance {
+		order.By = repository.SortByID
+	}
+	activities := []models.Activity{}
+	for id := range r.d.activities {
+		a, ok := r.d.activity(id)
+		if !ok || !matches(a, f) {
+			continue
+		}
+		if f.Query != "" {
+			if a.SearchRank, a.Headline, ok = search(a, terms); !ok {
+				continue
+			}
+		}
+		activities = append(activities, a)
+	}
+	r.d.sortActivities(activities, order)
+	return paginate(activities, page), int64(len(activities)), nil
+}
+
+func matches(a models.Activity, f repository.ActivityFilter) bool {
+	switch {
+	case f.MinBudget != nil && a.Budget < *f.MinBudget:
+		return false
+	case f.MaxBudget != nil && a.Budget > *f.MaxBudget:
+		return false
+	case f.MinTime != nil && a.Time < *f.MinTime:
+		return false
+	case f.MaxTime != nil && a.Time > *f.MaxTime:
+		return false
+	case len(f.Moods) > 0 && !matchMoods(a.Moods, f.Moods, f.MoodMatch):
+		return false
+	case !weather.Matches(a.Weather, f.Weather):
+		return false
+	case f.PeopleCount != nil && !a.FitsGroup(*f.PeopleCount):
+		return false
+	}
+	return true
+}
+
+func matchMoods(have, want []string, match repository.MoodMatch) bool {
+	for _, m := range want {
+		found := slices.Contains(have, m)
+		if found && match != repository.MoodMatchAll {
+			return true
+		}
+		if !found && match == repository.MoodMatchAll {
+			return false
+		}
+	}
+	return match == repository.MoodMatchAll
+}
+
+func (r *activityRepo) Get(ctx context.Context, id uint) (*models.Activity, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	a, ok := r.d.activity(id)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &a, nil
+}
+
+func (r *activityRepo) Create(ctx context.Context, activity *models.Activity) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.activityID++
+	activity.ID = r.d.activityID
+	activity.CreatedAt = time.Now()
+	// Рейтинг считается только по отзывам, как и в postgres
+	activity.RatingAvg, activity.RatingCount = 0, 0
+	r.d.activities[activity.ID] = *activity
+	return nil
+}
+
+func (r *activityRepo) Update(ctx context.Context, activity *models.Activity) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	stored, ok := r.d.activity(activity.ID)
+	if !ok {
+		return repository.ErrNotFound
+	}
+	activity.RatingAvg, activity.RatingCount = stored.RatingAvg, stored.RatingCount
+	r.d.activities[activity.ID] = *activity
+	return nil
+}
+
+func (r *activityRepo) Delete(ctx context.Context, id uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	a, ok := r.d.activity(id)
+	if !ok {
+		return nil
+	}
+	a.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.d.activities[id] = a
+	return nil
+}
+
+func (r *activityRepo) Count(ctx context.Context) (int64, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	return int64(len(r.d.activities)), nil
+}
+
+func (r *activityRepo) Suggest(ctx context.Context, prefix string, limit int) ([]models.ActivitySuggestion, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	suggestions := []models.ActivitySuggestion{}
+	terms := repository.SearchTerms(prefix)
+	if len(terms) == 0 {
+		return suggestions, nil
+	}
+	ranks := make(map[uint]int)
+	for id := range r.d.activities {
+		a, ok := r.d.activity(id)
+		if !ok {
+			continue
+		}
+		if rank := suggest(a.Name, terms); rank >= 0 {
+			ranks[a.ID] = rank
+			suggestions = append(suggestions, models.ActivitySuggestion{ID: a.ID, Name: a.Name})
+		}
+	}
+	sortSuggestions(suggestions, ranks)
+	return paginate(suggestions, repository.Page{Limit: limit}), nil
+}
@@ -0,0 +1,191 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type collectionRepo struct {
+	d *data
+}
+
+func (r *collectionRepo) List(ctx context.Context, userID uint) ([]models.Collection, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	collections := []models.Collection{}
+	for _, c := range r.d.collections {
+		if c.UserID == userID {
+			c.ItemCount = len(r.d.items(c.ID))
+			collections = append(collections, c)
+		}
+	}
+	sort.Slice(collections, func(i, j int) bool {
+		a, b := collections[i], collections[j]
+		if a.IsDefault != b.IsDefault {
+			return a.IsDefault
+		}
+		return a.ID < b.ID
+	})
+	return collections, nil
+}
+
+func (r *collectionRepo) Get(ctx context.Context, userID, id uint) (*models.Collection, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	c, ok := r.d.collections[id]
+	if !ok || c.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	c.Items = r.d.items(id)
+	c.ItemCount = len(c.Items)
+	return &c, nil
+}
+
+func (r *collectionRepo) Create(ctx context.Context, c *models.Collection) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.createCollection(c)
+	return nil
+}
+
+func (r *collectionRepo) Update(ctx context.Context, c *models.Collection) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	stored, ok := r.d.collections[c.ID]
+	if !ok || stored.UserID != c.UserID {
+		return repository.ErrNotFound
+	}
+	stored.Name, stored.Description, stored.Cover = c.Name, c.Description, c.Cover
+	stored.UpdatedAt = time.Now()
+	c.UpdatedAt = stored.UpdatedAt
+	r.d.collections[c.ID] = stored
+	return nil
+}
+
+func (r *collectionRepo) Delete(ctx context.Context, userID, id uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	c, ok := r.d.collections[id]
+	if !ok || c.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(r.d.collections, id)
+	for key := range r.d.collectionItems {
+		if key.CollectionID == id {
+			delete(r.d.collectionItems, key)
+		}
+	}
+	// Ссылки на коллекцию удаляются вместе с ней, как каскад в postgres
+	shares := r.d.shares[:0]
+	for _, s := range r.d.shares {
+		if s.CollectionID == nil || *s.CollectionID != id {
+			shares = append(shares, s)
+		}
+	}
+	r.d.shares = shares
+	return nil
+}
+
+func (r *collectionRepo) AddItem(ctx context.Context, item *models.CollectionItem) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	return r.d.addItem(item)
+}
+
+func (r *collectionRepo) UpdateItem(ctx context.Context, item *models.CollectionItem) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	key := collectionItemKey{CollectionID: item.CollectionID, ActivityID: item.ActivityID}
+	stored, ok := r.d.collectionItems[key]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	stored.Note = item.Note
+	r.d.collectionItems[key] = stored
+	return nil
+}
+
+func (r *collectionRepo) RemoveItem(ctx context.Context, collectionID, activityID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	delete(r.d.collectionItems, collectionItemKey{CollectionID: collectionID, ActivityID: activityID})
+	return nil
+}
+
+func (r *collectionRepo) Reorder(ctx context.Context, collectionID uint, activityIDs []uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for i, id := range activityIDs {
+		key := collectionItemKey{CollectionID: collectionID, ActivityID: id}
+		if item, ok := r.d.collectionItems[key]; ok {
+			item.Position = i + 1
+			r.d.collectionItems[key] = item
+		}
+	}
+	return nil
+}
+
+// createCollection сохраняет коллекцию и заполняет id и время; вызывать под d.mu
+func (d *data) createCollection(c *models.Collection) {
+	d.collectionID++
+	c.ID = d.collectionID
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+	stored := *c
+	stored.Items = nil
+	d.collections[c.ID] = stored
+}
+
+// defaultCollection возвращает id коллекции по умолчанию пользователя; вызывать под d.mu
+func (d *data) defaultCollection(userID uint) (uint, bool) {
+	for _, c := range d.collections {
+		if c.UserID == userID && c.IsDefault {
+			return c.ID, true
+		}
+	}
+	return 0, false
+}
+
+// addItem добавляет активность в конец коллекции; вызывать под d.mu на запись
+func (d *data) addItem(item *models.CollectionItem) error {
+	key := collectionItemKey{CollectionID: item.CollectionID, ActivityID: item.ActivityID}
+	if _, ok := d.collectionItems[key]; ok {
+		return repository.ErrAlreadyExists
+	}
+	item.Position = 1
+	for k, v := range d.collectionItems {
+		if k.CollectionID == item.CollectionID && v.Position >= item.Position {
+			item.Position = v.Position + 1
+		}
+	}
+	item.CreatedAt = time.Now()
+	stored := *item
+	stored.Activity = nil
+	d.collectionItems[key] = stored
+	return nil
+}
+
+// items возвращает состав коллекции по порядку с активностями, удалённые пропускаются; вызывать под d.mu
+func (d *data) items(collectionID uint) []models.CollectionItem {
+	items := []models.CollectionItem{}
+	for key, item := range d.collectionItems {
+		if key.CollectionID != collectionID {
+			continue
+		}
+		if a, ok := d.activity(key.ActivityID); ok {
+			item.Activity = &a
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position != items[j].Position {
+			return items[i].Position < items[j].Position
+		}
+		return items[i].ActivityID < items[j].ActivityID
+	})
+	return items
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type completionRepo struct {
+	d *data
+}
+
+func (r *completionRepo) Add(ctx context.Context, completion *models.ActivityCompletion) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.completionID++
+	completion.ID = r.d.completionID
+	stored := *completion
+	stored.Activity = nil
+	r.d.completions = append(r.d.completions, stored)
+	return nil
+}
+
+func (r *completionRepo) ListSince(ctx context.Context, userID uint, from time.Time) ([]models.ActivityCompletion, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	completions := []models.ActivityCompletion{}
+	for _, c := range r.d.completions {
+		if c.UserID != userID || c.CompletedAt.Before(from) {
+			continue
+		}
+		if a, ok := r.d.activity(c.ActivityID); ok {
+			c.Activity = &a
+		}
+		completions = append(completions, c)
+	}
+	sort.SliceStable(completions, func(i, j int) bool {
+		if !completions[i].CompletedAt.Equal(completions[j].CompletedAt) {
+			return completions[i].CompletedAt.After(completions[j].CompletedAt)
+		}
+		return completions[i].ID > completions[j].ID
+	})
+	return completions, nil
+}
+
+func (r *completionRepo) Each(ctx context.Context, userID uint, fn func(models.ActivityCompletion) error) error {
+	completions, err := r.ListSince(ctx, userID, time.Time{})
+	if err != nil {
+		return err
+	}
+	return each(completions, fn)
+}
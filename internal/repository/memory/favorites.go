@@ -0,0 +1,72 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type favoriteRepo struct {
+	d *data
+}
+
+func (r *favoriteRepo) List(ctx context.Context, userID uint, order repository.ActivityOrder, page repository.Page) ([]models.Activity, int64, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	activities := []models.Activity{}
+	if id, ok := r.d.defaultCollection(userID); ok {
+		for _, item := range r.d.items(id) {
+			a := *item.Activity
+			a.FavoritedAt = &item.CreatedAt
+			activities = append(activities, a)
+		}
+	}
+	r.d.sortActivities(activities, order)
+	return paginate(activities, page), int64(len(activities)), nil
+}
+
+func (r *favoriteRepo) Add(ctx context.Context, userID, activityID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	id, ok := r.d.defaultCollection(userID)
+	if !ok {
+		c := models.Collection{UserID: userID, Name: models.DefaultCollectionName, IsDefault: true}
+		r.d.createCollection(&c)
+		id = c.ID
+	}
+	return r.d.addItem(&models.CollectionItem{CollectionID: id, ActivityID: activityID})
+}
+
+func (r *favoriteRepo) Remove(ctx context.Context, userID, activityID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	if id, ok := r.d.defaultCollection(userID); ok {
+		delete(r.d.collectionItems, collectionItemKey{CollectionID: id, ActivityID: activityID})
+	}
+	return nil
+}
+
+func (r *favoriteRepo) Favorited(ctx context.Context, userID uint, activityIDs []uint) ([]uint, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	ids := []uint{}
+	collectionID, ok := r.d.defaultCollection(userID)
+	if !ok {
+		return ids, nil
+	}
+	for _, id := range activityIDs {
+		if _, ok := r.d.collectionItems[collectionItemKey{CollectionID: collectionID, ActivityID: id}]; ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *favoriteRepo) Each(ctx context.Context, userID uint, fn func(models.Activity) error) error {
+	activities, _, err := r.List(ctx, userID, repository.ActivityOrder{By: repository.SortByID}, repository.Page{})
+	if err != nil {
+		return err
+	}
+	return each(activities, fn)
+}
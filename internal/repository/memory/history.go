@@ -0,0 +1,118 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type historyRepo struct {
+	d *data
+}
+
+func (r *historyRepo) List(ctx context.Context, userID uint, filter repository.HistoryFilter, page repository.Page) ([]models.History, int64, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	// history хранится в порядке добавления, идём с конца
+	entries := []models.History{}
+	seen := make(map[uint]bool)
+	for i := len(r.d.history) - 1; i >= 0; i-- {
+		h := r.d.history[i]
+		if h.UserID != userID ||
+			(!filter.From.IsZero() && h.ViewedAt.Before(filter.From)) ||
+			(!filter.To.IsZero() && !h.ViewedAt.Before(filter.To)) {
+			continue
+		}
+		if filter.Distinct && seen[h.ActivityID] {
+			continue
+		}
+		seen[h.ActivityID] = true
+		a, ok := r.d.activity(h.ActivityID)
+		if !ok {
+			continue
+		}
+		h.Activity = &a
+		entries = append(entries, h)
+	}
+	return paginate(entries, page), int64(len(entries)), nil
+}
+
+func (r *historyRepo) Add(ctx context.Context, entry *models.History) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.historyID++
+	entry.ID = r.d.historyID
+	entry.ViewedAt = time.Now()
+	r.d.history = append(r.d.history, *entry)
+	return nil
+}
+
+func (r *historyRepo) Delete(ctx context.Context, userID, id uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for i, h := range r.d.history {
+		if h.ID == id && h.UserID == userID {
+			r.d.history = append(r.d.history[:i], r.d.history[i+1:]...)
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *historyRepo) Clear(ctx context.Context, userID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	history := r.d.history[:0]
+	for _, h := range r.d.history {
+		if h.UserID != userID {
+			history = append(history, h)
+		}
+	}
+	r.d.history = history
+	return nil
+}
+
+func (r *historyRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	history := r.d.history[:0]
+	for _, h := range r.d.history {
+		if !h.ViewedAt.Before(before) {
+			history = append(history, h)
+		}
+	}
+	deleted := int64(len(r.d.history) - len(history))
+	r.d.history = history
+	return deleted, nil
+}
+
+func (r *historyRepo) ListSince(ctx context.Context, userID uint, from time.Time) ([]models.History, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	history := []models.History{}
+	for i := len(r.d.history) - 1; i >= 0; i-- {
+		h := r.d.history[i]
+		if h.UserID == userID && !h.ViewedAt.Before(from) {
+			history = append(history, h)
+		}
+	}
+	return history, nil
+}
+
+func (r *historyRepo) Each(ctx context.Context, userID uint, fn func(models.History) error) error {
+	r.d.mu.RLock()
+	var history []models.History
+	for _, h := range r.d.history {
+		if h.UserID != userID {
+			continue
+		}
+		if a, ok := r.d.activity(h.ActivityID); ok {
+			h.Activity = &a
+		}
+		history = append(history, h)
+	}
+	r.d.mu.RUnlock()
+	return each(history, fn)
+}
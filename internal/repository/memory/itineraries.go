@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type itineraryRepo struct {
+	d *data
+}
+
+func (r *itineraryRepo) Create(ctx context.Context, it *models.Itinerary) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.itineraryID++
+	it.ID = r.d.itineraryID
+	it.CreatedAt = time.Now()
+	stored := *it
+	stored.Items = make([]models.ItineraryItem, len(it.Items))
+	for i := range it.Items {
+		r.d.itineraryItemID++
+		it.Items[i].ID = r.d.itineraryItemID
+		it.Items[i].ItineraryID = it.ID
+		stored.Items[i] = it.Items[i]
+		stored.Items[i].Activity = nil
+	}
+	r.d.itineraries[it.ID] = stored
+	return nil
+}
+
+func (r *itineraryRepo) List(ctx context.Context, userID uint, page repository.Page) ([]models.Itinerary, int64, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	itineraries := []models.Itinerary{}
+	for _, it := range r.d.itineraries {
+		if it.UserID == userID {
+			itineraries = append(itineraries, it)
+		}
+	}
+	sort.Slice(itineraries, func(i, j int) bool { return itineraries[i].ID > itineraries[j].ID })
+	total := int64(len(itineraries))
+	itineraries = paginate(itineraries, page)
+	for i := range itineraries {
+		itineraries[i] = r.d.withItems(itineraries[i])
+	}
+	return itineraries, total, nil
+}
+
+func (r *itineraryRepo) Get(ctx context.Context, userID, id uint) (*models.Itinerary, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	it, ok := r.d.itineraries[id]
+	if !ok || it.UserID != userID {
+		return nil, repository.ErrNotFound
+	}
+	it = r.d.withItems(it)
+	return &it, nil
+}
+
+func (r *itineraryRepo) Delete(ctx context.Context, userID, id uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	it, ok := r.d.itineraries[id]
+	if !ok || it.UserID != userID {
+		return repository.ErrNotFound
+	}
+	delete(r.d.itineraries, id)
+	return nil
+}
+
+// withItems копирует пункты плана и подставляет в них активности; вызывать под d.mu
+func (d *data) withItems(it models.Itinerary) models.Itinerary {
+	items := make([]models.ItineraryItem, len(it.Items))
+	for i, item := range it.Items {
+		if a, ok := d.activity(item.ActivityID); ok {
+			item.Activity = &a
+		}
+		items[i] = item
+	}
+	it.Items = items
+	return it
+}
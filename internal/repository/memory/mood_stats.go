@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type moodStatRepo struct {
+	d *data
+}
+
+func (r *moodStatRepo) Add(ctx context.Context, entry *models.MoodEntry) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.moodEntryID++
+	entry.ID = r.d.moodEntryID
+	if entry.Tags == nil {
+		entry.Tags = pq.StringArray{}
+	}
+	r.d.moodEntries = append(r.d.moodEntries, *entry)
+	return nil
+}
+
+func (r *moodStatRepo) LatestOn(ctx context.Context, userID uint, date time.Time) (*models.MoodEntry, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	entries := r.d.entriesBetween(userID, date, date)
+	if len(entries) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return &entries[len(entries)-1], nil
+}
+
+func (r *moodStatRepo) Update(ctx context.Context, entry *models.MoodEntry) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for i, e := range r.d.moodEntries {
+		if e.ID == entry.ID && e.UserID == entry.UserID {
+			e.Mood, e.Intensity, e.Note, e.Tags = entry.Mood, entry.Intensity, entry.Note, entry.Tags
+			r.d.moodEntries[i] = e
+			return nil
+		}
+	}
+	return repository.ErrNotFound
+}
+
+func (r *moodStatRepo) DeleteDay(ctx context.Context, userID uint, date time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	kept := r.d.moodEntries[:0]
+	for _, e := range r.d.moodEntries {
+		if e.UserID != userID || !e.Date.Equal(date) {
+			kept = append(kept, e)
+		}
+	}
+	deleted := len(r.d.moodEntries) - len(kept)
+	r.d.moodEntries = kept
+	if deleted == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *moodStatRepo) ListEntries(ctx context.Context, userID uint, from, to time.Time) ([]models.MoodEntry, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	return r.d.entriesBetween(userID, from, to), nil
+}
+
+func (r *moodStatRepo) ListDays(ctx context.Context, userID uint, from, to time.Time) ([]models.MoodStat, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	stats := []models.MoodStat{}
+	var sum int
+	for _, e := range r.d.entriesBetween(userID, from, to) {
+		// Записи идут по времени, так что последняя запись дня перезаписывает настроение
+		if n := len(stats); n > 0 && stats[n-1].Date.Equal(e.Date) {
+			stats[n-1].ID, stats[n-1].Mood = e.ID, e.Mood
+			stats[n-1].Entries++
+		} else {
+			stats = append(stats, models.MoodStat{ID: e.ID, UserID: e.UserID, Date: e.Date, Mood: e.Mood, Entries: 1})
+			sum = 0
+		}
+		sum += e.Intensity
+		s := &stats[len(stats)-1]
+		s.Intensity = math.Round(float64(sum)/float64(s.Entries)*10) / 10
+	}
+	return stats, nil
+}
+
+func (r *moodStatRepo) LoggedDays(ctx context.Context, userID uint) ([]time.Time, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	days := []time.Time{}
+	for _, e := range r.d.entriesBetween(userID, time.Time{}, time.Time{}) {
+		if n := len(days); n == 0 || !days[n-1].Equal(e.Date) {
+			days = append(days, e.Date)
+		}
+	}
+	return days, nil
+}
+
+// entriesBetween — записи пользователя за дни from..to по возрастанию дня и времени;
+// нулевой to — без верхней границы. Вызывать под d.mu
+func (d *data) entriesBetween(userID uint, from, to time.Time) []models.MoodEntry {
+	entries := []models.MoodEntry{}
+	for _, e := range d.moodEntries {
+		if e.UserID == userID && !e.Date.Before(from) && (to.IsZero() || !e.Date.After(to)) {
+			entries = append(entries, e)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Date.Equal(entries[j].Date) {
+			return entries[i].Date.Before(entries[j].Date)
+		}
+		if !entries[i].LoggedAt.Equal(entries[j].LoggedAt) {
+			return entries[i].LoggedAt.Before(entries[j].LoggedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+func (r *moodStatRepo) EachEntry(ctx context.Context, userID uint, fn func(models.MoodEntry) error) error {
+	r.d.mu.RLock()
+	entries := r.d.entriesBetween(userID, time.Time{}, time.Time{})
+	r.d.mu.RUnlock()
+	return each(entries, fn)
+}
@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type moodRepo struct {
+	d *data
+}
+
+func (r *moodRepo) List(ctx context.Context) ([]models.Mood, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	moods := make([]models.Mood, 0, len(r.d.moods))
+	for _, m := range r.d.moods {
+		moods = append(moods, m)
+	}
+	sort.Slice(moods, func(i, j int) bool {
+		if moods[i].Position != moods[j].Position {
+			return moods[i].Position < moods[j].Position
+		}
+		return moods[i].Slug < moods[j].Slug
+	})
+	return moods, nil
+}
+
+func (r *moodRepo) Create(ctx context.Context, mood *models.Mood) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	if _, ok := r.d.moods[mood.Slug]; ok {
+		return repository.ErrAlreadyExists
+	}
+	r.d.moods[mood.Slug] = *mood
+	return nil
+}
+
+func (r *moodRepo) Update(ctx context.Context, mood *models.Mood) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	if _, ok := r.d.moods[mood.Slug]; !ok {
+		return repository.ErrNotFound
+	}
+	r.d.moods[mood.Slug] = *mood
+	return nil
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type pickRepo struct {
+	d *data
+}
+
+func (r *pickRepo) Add(ctx context.Context, pick *models.ActivityPick) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.pickID++
+	pick.ID = r.d.pickID
+	pick.PickedAt = time.Now()
+	r.d.picks = append(r.d.picks, *pick)
+	return nil
+}
+
+func (r *pickRepo) Recent(ctx context.Context, userID uint, limit int) ([]models.ActivityPick, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	picks := []models.ActivityPick{}
+	for i := len(r.d.picks) - 1; i >= 0 && len(picks) < limit; i-- {
+		if r.d.picks[i].UserID == userID {
+			picks = append(picks, r.d.picks[i])
+		}
+	}
+	return picks, nil
+}
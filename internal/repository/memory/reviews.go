@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type reviewRepo struct {
+	d *data
+}
+
+func (r *reviewRepo) Upsert(ctx context.Context, review *models.Review) (bool, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	if _, ok := r.d.activities[review.ActivityID]; !ok {
+		return false, repository.ErrNotFound
+	}
+	now := time.Now()
+	created := true
+	if i, ok := r.d.review(review.UserID, review.ActivityID); ok {
+		existing := &r.d.reviews[i]
+		existing.Rating, existing.Text, existing.UpdatedAt = review.Rating, review.Text, now
+		*review = *existing
+		created = false
+	} else {
+		r.d.reviewID++
+		review.ID = r.d.reviewID
+		review.Hidden = false
+		review.CreatedAt, review.UpdatedAt = now, now
+		r.d.reviews = append(r.d.reviews, *review)
+	}
+	r.d.updateRating(review.ActivityID)
+	return created, nil
+}
+
+func (r *reviewRepo) Get(ctx context.Context, userID, activityID uint) (*models.Review, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	i, ok := r.d.review(userID, activityID)
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	review := r.d.withAuthor(r.d.reviews[i])
+	return &review, nil
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, userID, activityID uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	i, ok := r.d.review(userID, activityID)
+	if !ok {
+		return repository.ErrNotFound
+	}
+	r.d.reviews = append(r.d.reviews[:i], r.d.reviews[i+1:]...)
+	r.d.updateRating(activityID)
+	return nil
+}
+
+func (r *reviewRepo) List(ctx context.Context, activityID uint, includeHidden bool, page repository.Page) ([]models.Review, int64, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	// reviews хранятся в порядке создания, идём с конца
+	reviews := []models.Review{}
+	for i := len(r.d.reviews) - 1; i >= 0; i-- {
+		review := r.d.reviews[i]
+		if review.ActivityID == activityID && (includeHidden || !review.Hidden) {
+			reviews = append(reviews, r.d.withAuthor(review))
+		}
+	}
+	return paginate(reviews, page), int64(len(reviews)), nil
+}
+
+func (r *reviewRepo) SetHidden(ctx context.Context, id uint, hidden bool) (*models.Review, error) {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for i := range r.d.reviews {
+		if r.d.reviews[i].ID != id {
+			continue
+		}
+		r.d.reviews[i].Hidden = hidden
+		r.d.updateRating(r.d.reviews[i].ActivityID)
+		review := r.d.withAuthor(r.d.reviews[i])
+		return &review, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+// review возвращает индекс отзыва пользователя об активности; вызывать под d.mu
+func (d *data) review(userID, activityID uint) (int, bool) {
+	for i, review := range d.reviews {
+		if review.UserID == userID && review.ActivityID == activityID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// withAuthor подставляет имя автора в копию отзыва; вызывать под d.mu
+func (d *data) withAuthor(review models.Review) models.Review {
+	review.Username = d.users[review.UserID].Username
+	return review
+}
+
+// updateRating пересчитывает рейтинг активности по видимым отзывам; вызывать под d.mu на запись
+func (d *data) updateRating(activityID uint) {
+	a, ok := d.activities[activityID]
+	if !ok {
+		return
+	}
+	sum, count := 0, 0
+	for _, review := range d.reviews {
+		if review.ActivityID == activityID && !review.Hidden {
+			sum += review.Rating
+			count++
+		}
+	}
+	a.RatingAvg, a.RatingCount = 0, count
+	if count > 0 {
+		a.RatingAvg = math.Round(float64(sum)/float64(count)*100) / 100
+	}
+	d.activities[activityID] = a
+}
@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+// search — упрощённая замена полнотекстового поиска PostgreSQL: все слова запроса
+// должны встречаться в названии или описании. Совпадение в названии весит больше.
+// Возвращает ранг, подсвеченный фрагмент и признак совпадения.
+func search(a models.Activity, terms []string) (float64, string, bool) {
+	name, description := strings.ToLower(a.Name), strings.ToLower(a.Description)
+	var rank float64
+	for _, term := range terms {
+		switch {
+		case strings.Contains(name, term):
+			rank += 1
+		case strings.Contains(description, term):
+			rank += 0.4
+		default:
+			return 0, "", false
+		}
+	}
+	return rank / float64(len(terms)), highlight(a.Name+" — "+a.Description, terms), true
+}
+
+// highlight оборачивает в <b>…</b> слова, содержащие какой-либо из terms
+func highlight(text string, terms []string) string {
+	words := strings.Fields(text)
+	for i, w := range words {
+		lw := strings.ToLower(w)
+		for _, term := range terms {
+			if strings.Contains(lw, term) {
+				words[i] = "<b>" + w + "</b>"
+				break
+			}
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// suggest ранжирует названия для автодополнения: 0 — слово названия начинается
+// с запроса, 1 — отличается от него на одну-две опечатки, -1 — не подходит
+func suggest(name string, terms []string) int {
+	words := repository.SearchTerms(name)
+	best := 0
+	for _, term := range terms {
+		termBest := -1
+		for _, w := range words {
+			if strings.HasPrefix(w, term) {
+				termBest = 0
+				break
+			}
+			if typoMatch(w, term) {
+				termBest = 1
+			}
+		}
+		if termBest < 0 {
+			return -1
+		}
+		best = max(best, termBest)
+	}
+	return best
+}
+
+// typoMatch сравнивает term с началом слова той же длины с допуском
+// в одну ошибку (две — для запросов от 6 букв)
+func typoMatch(word, term string) bool {
+	n := utf8.RuneCountInString(term)
+	if n < 3 {
+		return false
+	}
+	allowed := 1
+	if n >= 6 {
+		allowed = 2
+	}
+	w := []rune(word)
+	if len(w) > n {
+		w = w[:n]
+	}
+	return levenshtein(w, []rune(term)) <= allowed
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func sortSuggestions(suggestions []models.ActivitySuggestion, ranks map[uint]int) {
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if ranks[a.ID] != ranks[b.ID] {
+			return ranks[a.ID] < ranks[b.ID]
+		}
+		return a.Name < b.Name
+	})
+}
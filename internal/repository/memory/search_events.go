@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type searchEventRepo struct {
+	d *data
+}
+
+func (r *searchEventRepo) Add(ctx context.Context, event *models.SearchEvent) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.searchEventID++
+	event.ID = r.d.searchEventID
+	event.CreatedAt = time.Now()
+	r.d.searchEvents = append(r.d.searchEvents, *event)
+	return nil
+}
+
+func (r *searchEventRepo) ListSince(ctx context.Context, userID uint, from time.Time) ([]models.SearchEvent, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	events := []models.SearchEvent{}
+	for i := len(r.d.searchEvents) - 1; i >= 0; i-- {
+		e := r.d.searchEvents[i]
+		if e.UserID == userID && !e.CreatedAt.Before(from) {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+func (r *searchEventRepo) Stats(ctx context.Context, from time.Time, limit int) (repository.SearchStats, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	var stats repository.SearchStats
+	users := make(map[uint]bool)
+	queries := make(map[string]int64)
+	zero := make(map[string]int64)
+	moods := make(map[string]int64)
+	for _, e := range r.d.searchEvents {
+		if e.CreatedAt.Before(from) {
+			continue
+		}
+		stats.Total++
+		users[e.UserID] = true
+		if q := strings.ToLower(e.Query); q != "" {
+			queries[q]++
+			if e.ResultCount == 0 {
+				zero[q]++
+			}
+		}
+		for _, m := range e.Moods {
+			moods[m]++
+		}
+	}
+	stats.Users = int64(len(users))
+	stats.TopQueries = top(queries, limit)
+	stats.ZeroResults = top(zero, limit)
+	stats.TopMoods = top(moods, limit)
+	return stats, nil
+}
+
+// top — limit самых частых значений, при равенстве по алфавиту
+func top(counts map[string]int64, limit int) []repository.TermCount {
+	result := make([]repository.TermCount, 0, len(counts))
+	for term, n := range counts {
+		result = append(result, repository.TermCount{Term: term, Count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Term < result[j].Term
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
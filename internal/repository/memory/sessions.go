@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type sessionRepo struct {
+	d *data
+}
+
+func (r *sessionRepo) Create(ctx context.Context, session *models.Session, token *models.RefreshToken) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	if _, ok := r.d.sessions[session.ID]; ok {
+		return repository.ErrAlreadyExists
+	}
+	session.CreatedAt = time.Now()
+	r.d.sessions[session.ID] = *session
+	r.d.insertToken(token)
+	return nil
+}
+
+func (r *sessionRepo) IsActive(ctx context.Context, id string) (bool, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	s, ok := r.d.sessions[id]
+	return ok && s.RevokedAt == nil, nil
+}
+
+func (r *sessionRepo) Revoke(ctx context.Context, id string) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	r.d.revokeSession(id, time.Now())
+	return nil
+}
+
+func (r *sessionRepo) Rotate(ctx context.Context, tokenHash string, next *models.RefreshToken, now time.Time) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	token, ok := r.d.refreshTokens[tokenHash]
+	if !ok {
+		return repository.ErrTokenInvalid
+	}
+	session, ok := r.d.sessions[token.SessionID]
+	if !ok || session.RevokedAt != nil {
+		return repository.ErrTokenInvalid
+	}
+	if token.UsedAt != nil {
+		r.d.revokeSession(session.ID, now)
+		return repository.ErrTokenReused
+	}
+	if now.After(token.ExpiresAt) {
+		return repository.ErrTokenInvalid
+	}
+	token.UsedAt = &now
+	r.d.refreshTokens[tokenHash] = token
+	next.SessionID = token.SessionID
+	next.UserID = token.UserID
+	r.d.insertToken(next)
+	return nil
+}
+
+// insertToken вызывать под d.mu
+func (d *data) insertToken(token *models.RefreshToken) {
+	d.tokenID++
+	token.ID = d.tokenID
+	token.CreatedAt = time.Now()
+	d.refreshTokens[token.TokenHash] = *token
+}
+
+// revokeSession вызывать под d.mu
+func (d *data) revokeSession(id string, now time.Time) {
+	s, ok := d.sessions[id]
+	if !ok || s.RevokedAt != nil {
+		return
+	}
+	s.RevokedAt = &now
+	d.sessions[id] = s
+}
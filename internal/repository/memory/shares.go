@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type shareRepo struct {
+	d *data
+}
+
+func (r *shareRepo) Create(ctx context.Context, s *models.Share) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for _, existing := range r.d.shares {
+		if existing.Slug == s.Slug {
+			return repository.ErrAlreadyExists
+		}
+	}
+	r.d.shareID++
+	s.ID = r.d.shareID
+	s.CreatedAt = time.Now()
+	r.d.shares = append(r.d.shares, *s)
+	return nil
+}
+
+func (r *shareRepo) Get(ctx context.Context, slug string) (*models.Share, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	for _, s := range r.d.shares {
+		if s.Slug == slug {
+			return &s, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *shareRepo) List(ctx context.Context, userID uint) ([]models.Share, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	shares := []models.Share{}
+	// Добавляются по порядку, поэтому новые — в конце
+	for i := len(r.d.shares) - 1; i >= 0; i-- {
+		if r.d.shares[i].UserID == userID {
+			shares = append(shares, r.d.shares[i])
+		}
+	}
+	return shares, nil
+}
+
+func (r *shareRepo) Revoke(ctx context.Context, userID uint, slug string) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for i, s := range r.d.shares {
+		if s.Slug != slug || s.UserID != userID {
+			continue
+		}
+		if s.RevokedAt == nil {
+			now := time.Now()
+			r.d.shares[i].RevokedAt = &now
+		}
+		return nil
+	}
+	return repository.ErrNotFound
+}
+
+func (r *shareRepo) AddView(ctx context.Context, id uint) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for i := range r.d.shares {
+		if r.d.shares[i].ID == id {
+			r.d.shares[i].ViewCount++
+		}
+	}
+	return nil
+}
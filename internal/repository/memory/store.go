@@ -0,0 +1,192 @@
+// Package memory реализует репозитории в памяти процесса.
+//
+// Используется в тестах и для запуска API без PostgreSQL (STORAGE=memory).
+// Все репозитории одного Store разделяют общие данные под одним мьютексом,
+// поэтому, например, избранное видит мягкое удаление активностей.
+package memory
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type collectionItemKey struct {
+	CollectionID uint
+	ActivityID   uint
+}
+
+type data struct {
+	mu sync.RWMutex
+
+	users  map[uint]models.User
+	userID uint
+
+	sessions      map[string]models.Session
+	refreshTokens map[string]models.RefreshToken // по хешу токена
+	tokenID       uint
+
+	activities map[uint]models.Activity
+	activityID uint
+
+	collections     map[uint]models.Collection // без Items
+	collectionID    uint
+	collectionItems map[collectionItemKey]models.CollectionItem // без Activity
+
+	shares  []models.Share
+	shareID uint
+
+	reviews  []models.Review
+	reviewID uint
+
+	history   []models.History
+	historyID uint
+
+	moodEntries []models.MoodEntry
+	moodEntryID uint
+
+	picks  []models.ActivityPick
+	pickID uint
+
+	itineraries     map[uint]models.Itinerary
+	itineraryID     uint
+	itineraryItemID uint
+
+	moods map[string]models.Mood // по slug
+
+	searchEvents  []models.SearchEvent
+	searchEventID uint
+
+	completions  []models.ActivityCompletion
+	completionID uint
+}
+
+// NewStore создаёт пустое хранилище
+func NewStore() *repository.Store {
+	d := &data{
+		users:           make(map[uint]models.User),
+		sessions:        make(map[string]models.Session),
+		refreshTokens:   make(map[string]models.RefreshToken),
+		activities:      make(map[uint]models.Activity),
+		collections:     make(map[uint]models.Collection),
+		collectionItems: make(map[collectionItemKey]models.CollectionItem),
+		itineraries:     make(map[uint]models.Itinerary),
+		moods:           make(map[string]models.Mood),
+	}
+	return &repository.Store{
+		Users:        &userRepo{d},
+		Sessions:     &sessionRepo{d},
+		Activities:   &activityRepo{d},
+		Favorites:    &favoriteRepo{d},
+		History:      &historyRepo{d},
+		MoodStats:    &moodStatRepo{d},
+		Picks:        &pickRepo{d},
+		Itineraries:  &itineraryRepo{d},
+		Moods:        &moodRepo{d},
+		SearchEvents: &searchEventRepo{d},
+		Completions:  &completionRepo{d},
+		Collections:  &collectionRepo{d},
+		Shares:       &shareRepo{d},
+		Reviews:      &reviewRepo{d},
+	}
+}
+
+// activity возвращает неудалённую активность; вызывать под d.mu
+func (d *data) activity(id uint) (models.Activity, bool) {
+	a, ok := d.activities[id]
+	if !ok || a.DeletedAt.Valid {
+		return models.Activity{}, false
+	}
+	return a, true
+}
+
+// popularity — сколько раз активность добавлена в избранное; вызывать под d.mu
+func (d *data) popularity(activityID uint) int {
+	n := 0
+	for key := range d.collectionItems {
+		if key.ActivityID == activityID && d.collections[key.CollectionID].IsDefault {
+			n++
+		}
+	}
+	return n
+}
+
+// sortActivities повторяет порядок postgres-реализации; вызывать под d.mu
+func (d *data) sortActivities(activities []models.Activity, order repository.ActivityOrder) {
+	var popularity map[uint]int
+	if order.By == repository.SortByPopularity {
+		popularity = make(map[uint]int, len(activities))
+		for _, a := range activities {
+			popularity[a.ID] = d.popularity(a.ID)
+		}
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		a, b := activities[i], activities[j]
+		var cmp int
+		switch order.By {
+		case repository.SortByBudget:
+			cmp = compare(a.Budget, b.Budget)
+		case repository.SortByTime:
+			cmp = compare(a.Time, b.Time)
+		case repository.SortByCreatedAt:
+			cmp = a.CreatedAt.Compare(b.CreatedAt)
+		case repository.SortByName:
+			cmp = strings.Compare(a.Name, b.Name)
+		case repository.SortByPopularity:
+			cmp = compare(popularity[a.ID], popularity[b.ID])
+		case repository.SortByRating:
+			cmp = compare(a.RatingAvg, b.RatingAvg)
+			if cmp == 0 {
+				cmp = compare(a.RatingCount, b.RatingCount)
+			}
+		case repository.SortByRelevance:
+			cmp = compare(a.SearchRank, b.SearchRank)
+		case repository.SortByFavoritedAt:
+			if a.FavoritedAt != nil && b.FavoritedAt != nil {
+				cmp = a.FavoritedAt.Compare(*b.FavoritedAt)
+			}
+		}
+		if cmp == 0 {
+			cmp = compare(a.ID, b.ID)
+		}
+		if order.Desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+}
+
+func compare[T int | uint | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func paginate[T any](items []T, page repository.Page) []T {
+	if page.Offset >= len(items) {
+		return items[:0]
+	}
+	items = items[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+	return items
+}
+
+// each вызывает fn для каждой строки снимка; снимок делается под d.mu,
+// а fn вызывается уже без блокировки
+func each[T any](rows []T, fn func(T) error) error {
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
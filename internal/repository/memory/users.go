@@ -0,0 +1,65 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+)
+
+type userRepo struct {
+	d *data
+}
+
+func (r *userRepo) Create(ctx context.Context, user *models.User) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	for _, u := range r.d.users {
+		if u.Username == user.Username {
+			return repository.ErrAlreadyExists
+		}
+	}
+	r.d.userID++
+	user.ID = r.d.userID
+	if user.Role == "" {
+		user.Role = "user"
+	}
+	user.CreatedAt = time.Now()
+	r.d.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepo) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	u, ok := r.d.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &u, nil
+}
+
+func (r *userRepo) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	r.d.mu.RLock()
+	defer r.d.mu.RUnlock()
+	for _, u := range r.d.users {
+		if u.Username == username {
+			return &u, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepo) UpdateProfile(ctx context.Context, user *models.User) error {
+	r.d.mu.Lock()
+	defer r.d.mu.Unlock()
+	u, ok := r.d.users[user.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	u.City = user.City
+	u.Timezone = user.Timezone
+	r.d.users[user.ID] = u
+	return nil
+}
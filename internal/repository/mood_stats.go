@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// Дни в методах ниже — календарные (полночь по UTC), как колонка date;
+// нулевой to — без верхней границы, обе границы включительно.
+type MoodStatRepository interface {
+	// Add добавляет запись в дневник настроения
+	Add(ctx context.Context, entry *models.MoodEntry) error
+	// LatestOn возвращает последнюю запись дня или ErrNotFound
+	LatestOn(ctx context.Context, userID uint, date time.Time) (*models.MoodEntry, error)
+	// Update сохраняет настроение, силу, заметку и теги записи
+	Update(ctx context.Context, entry *models.MoodEntry) error
+	// DeleteDay удаляет все записи дня; ErrNotFound, если их не было
+	DeleteDay(ctx context.Context, userID uint, date time.Time) error
+	// ListEntries возвращает записи пользователя за дни from..to, по возрастанию времени
+	ListEntries(ctx context.Context, userID uint, from, to time.Time) ([]models.MoodEntry, error)
+	// ListDays возвращает настроение по дням from..to, по возрастанию даты
+	ListDays(ctx context.Context, userID uint, from, to time.Time) ([]models.MoodStat, error)
+	// LoggedDays возвращает все дни, в которые у пользователя были записи, по возрастанию
+	LoggedDays(ctx context.Context, userID uint) ([]time.Time, error)
+	// EachEntry вызывает fn для каждой записи дневника пользователя, не загружая все сразу
+	EachEntry(ctx context.Context, userID uint, fn func(models.MoodEntry) error) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type MoodRepository interface {
+	// List возвращает весь словарь по position, затем по slug
+	List(ctx context.Context) ([]models.Mood, error)
+	// Create возвращает ErrAlreadyExists, если slug занят
+	Create(ctx context.Context, mood *models.Mood) error
+	// Update меняет подписи, эмодзи, цвет и позицию; slug не меняется
+	Update(ctx context.Context, mood *models.Mood) error
+}
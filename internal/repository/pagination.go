@@ -0,0 +1,7 @@
+package repository
+
+// Page — окно выборки. Limit <= 0 означает «без ограничения».
+type Page struct {
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type PickRepository interface {
+	Add(ctx context.Context, pick *models.ActivityPick) error
+	// Recent возвращает последние limit выборов пользователя, от новых к старым
+	Recent(ctx context.Context, userID uint, limit int) ([]models.ActivityPick, error)
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/weather"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type activityRepo struct {
+	db *gorm.DB
+}
+
+func (r *activityRepo) List(ctx context.Context, f repository.ActivityFilter, order repository.ActivityOrder, page repository.Page) ([]models.Activity, int64, error) {
+	var total int64
+	if err := filterActivities(r.db.WithContext(ctx).Model(&models.Activity{}), f).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	activities := []models.Activity{}
+	q := filterActivities(r.db.WithContext(ctx).Model(&models.Activity{}), f)
+	if f.Query != "" {
+		q = q.Select(`activities.*,
+			ts_rank(search_vector, websearch_to_tsquery('russian', ?)) AS search_rank,
+			ts_headline('russian', coalesce(name, '') || ' — ' || coalesce(description, ''),
+				websearch_to_tsquery('russian', ?), 'StartSel=<b>, StopSel=</b>, MaxWords=25, MinWords=8') AS headline`,
+			f.Query, f.Query)
+	} else if order.By == repository.SortByRelevance {
+		order.By = repository.SortByID
+	}
+	if err := paginate(orderActivities(q, order), page).Find(&activities).Error; err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
+
+func filterActivities(q *gorm.DB, f repository.ActivityFilter) *gorm.DB {
+	if f.Query != "" {
+		q = q.Where("search_vector @@ websearch_to_tsquery('russian', ?)", f.Query)
+	}
+	if f.MinBudget != nil {
+		q = q.Where("budget >= ?", *f.MinBudget)
+	}
+	if f.MaxBudget != nil {
+		q = q.Where("budget <= ?", *f.MaxBudget)
+	}
+	if f.MinTime != nil {
+		q = q.Where("time >= ?", *f.MinTime)
+	}
+	if f.MaxTime != nil {
+		q = q.Where("time <= ?", *f.MaxTime)
+	}
+	if len(f.Moods) > 0 {
+		if f.MoodMatch == repository.MoodMatchAll {
+			q = q.Where("moods @> ?::varchar(64)[]", pq.StringArray(f.Moods))
+		} else {
+			q = q.Where("moods && ?::varchar(64)[]", pq.StringArray(f.Moods))
+		}
+	}
+	if len(f.Weather) > 0 {
+		q = q.Where("(weather && ?::varchar(16)[] OR ? = ANY(weather))", pq.StringArray(f.Weather), weather.Any)
+	}
+	if f.PeopleCount != nil {
+		q = q.Where("min_people <= ? AND (max_people IS NULL OR max_people >= ?)", *f.PeopleCount, *f.PeopleCount)
+	}
+	return q
+}
+
+// orderActivities сортирует выборку из activities; id всегда добавляется последним ключом,
+// чтобы страницы не «плавали» при равных значениях
+func orderActivities(q *gorm.DB, order repository.ActivityOrder) *gorm.DB {
+	dir := " ASC"
+	if order.Desc {
+		dir = " DESC"
+	}
+	switch order.By {
+	case repository.SortByBudget, repository.SortByTime, repository.SortByCreatedAt, repository.SortByName:
+		q = q.Order("activities." + string(order.By) + dir)
+	case repository.SortByPopularity:
+		q = q.Order("(SELECT COUNT(*) FROM collection_items ci JOIN collections c ON c.id = ci.collection_id AND c.is_default" +
+			" WHERE ci.activity_id = activities.id)" + dir)
+	case repository.SortByRating:
+		q = q.Order("activities.rating_avg" + dir).Order("activities.rating_count" + dir)
+	case repository.SortByRelevance:
+		// search_rank вычисляется в List при непустом запросе
+		q = q.Order("search_rank" + dir)
+	case repository.SortByFavoritedAt:
+		// favorited_at выбирается в списке избранного
+		q = q.Order("favorited_at" + dir)
+	}
+	return q.Order("activities.id" + dir)
+}
+
+func (r *activityRepo) Get(ctx context.Context, id uint) (*models.Activity, error) {
+	var activity models.Activity
+	if err := r.db.WithContext(ctx).First(&activity, id).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &activity, nil
+}
+
+func (r *activityRepo) Create(ctx context.Context, activity *models.Activity) error {
+	return r.db.WithContext(ctx).Create(activity).Error
+}
+
+func (r *activityRepo) Update(ctx context.Context, activity *models.Activity) error {
+	return r.db.WithContext(ctx).Save(activity).Error
+}
+
+func (r *activityRepo) Delete(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Activity{}, id).Error
+}
+
+func (r *activityRepo) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.Activity{}).Count(&count).Error
+	return count, err
+}
+
+func (r *activityRepo) Suggest(ctx context.Context, prefix string, limit int) ([]models.ActivitySuggestion, error) {
+	suggestions := []models.ActivitySuggestion{}
+	terms := repository.SearchTerms(prefix)
+	if len(terms) == 0 {
+		return suggestions, nil
+	}
+	// Каждое слово ищем как префикс: "прог муз" -> "прог:* & муз:*"
+	tsquery := strings.Join(terms, ":* & ") + ":*"
+	text := strings.Join(terms, " ")
+	err := r.db.WithContext(ctx).Model(&models.Activity{}).
+		Select("id, name").
+		Where("search_vector @@ to_tsquery('russian', ?) OR word_similarity(?, lower(name)) > 0.3", tsquery, text).
+		Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "search_vector @@ to_tsquery('russian', ?) DESC, word_similarity(?, lower(name)) DESC, name",
+			Vars:               []interface{}{tsquery, text},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Scan(&suggestions).Error
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type collectionRepo struct {
+	db *gorm.DB
+}
+
+func (r *collectionRepo) List(ctx context.Context, userID uint) ([]models.Collection, error) {
+	collections := []models.Collection{}
+	err := r.db.WithContext(ctx).Model(&models.Collection{}).
+		Select("collections.*, (SELECT COUNT(*) FROM collection_items ci JOIN activities a ON a.id = ci.activity_id AND a.deleted_at IS NULL"+
+			" WHERE ci.collection_id = collections.id) AS item_count").
+		Where("user_id = ?", userID).
+		Order("is_default DESC, created_at, id").
+		Find(&collections).Error
+	return collections, err
+}
+
+func (r *collectionRepo) Get(ctx context.Context, userID, id uint) (*models.Collection, error) {
+	var collection models.Collection
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&collection).Error; err != nil {
+		return nil, translate(err)
+	}
+	var items []models.CollectionItem
+	err := r.db.WithContext(ctx).Preload("Activity").
+		Where("collection_id = ?", id).
+		Order("position, activity_id").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	// Удалённые активности Preload не подставляет
+	collection.Items = []models.CollectionItem{}
+	for _, item := range items {
+		if item.Activity != nil {
+			collection.Items = append(collection.Items, item)
+		}
+	}
+	collection.ItemCount = len(collection.Items)
+	return &collection, nil
+}
+
+func (r *collectionRepo) Create(ctx context.Context, c *models.Collection) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(c).Error
+}
+
+func (r *collectionRepo) Update(ctx context.Context, c *models.Collection) error {
+	c.UpdatedAt = time.Now()
+	res := r.db.WithContext(ctx).Model(&models.Collection{}).
+		Where("id = ? AND user_id = ?", c.ID, c.UserID).
+		Updates(map[string]any{"name": c.Name, "description": c.Description, "cover": c.Cover, "updated_at": c.UpdatedAt})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) Delete(ctx context.Context, userID, id uint) error {
+	// Состав удаляется каскадом
+	res := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Collection{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) AddItem(ctx context.Context, item *models.CollectionItem) error {
+	return addItem(r.db.WithContext(ctx), item)
+}
+
+func (r *collectionRepo) UpdateItem(ctx context.Context, item *models.CollectionItem) error {
+	res := r.db.WithContext(ctx).Model(&models.CollectionItem{}).
+		Where("collection_id = ? AND activity_id = ?", item.CollectionID, item.ActivityID).
+		Update("note", item.Note)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *collectionRepo) RemoveItem(ctx context.Context, collectionID, activityID uint) error {
+	return r.db.WithContext(ctx).
+		Delete(&models.CollectionItem{}, "collection_id = ? AND activity_id = ?", collectionID, activityID).Error
+}
+
+func (r *collectionRepo) Reorder(ctx context.Context, collectionID uint, activityIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range activityIDs {
+			err := tx.Model(&models.CollectionItem{}).
+				Where("collection_id = ? AND activity_id = ?", collectionID, id).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// addItem добавляет активность в конец коллекции и заполняет Position и CreatedAt
+func addItem(db *gorm.DB, item *models.CollectionItem) error {
+	var row struct {
+		Position  int
+		CreatedAt time.Time
+	}
+	err := db.Raw(`INSERT INTO collection_items (collection_id, activity_id, position, note)
+		SELECT ?, ?, COALESCE(MAX(position), 0) + 1, ? FROM collection_items WHERE collection_id = ?
+		RETURNING position, created_at`,
+		item.CollectionID, item.ActivityID, item.Note, item.CollectionID).Scan(&row).Error
+	if err != nil {
+		return translate(err)
+	}
+	item.Position, item.CreatedAt = row.Position, row.CreatedAt
+	return nil
+}
+
+// defaultCollection возвращает id коллекции по умолчанию, при необходимости создавая её
+func defaultCollection(tx *gorm.DB, userID uint) (uint, error) {
+	c := models.Collection{UserID: userID, Name: models.DefaultCollectionName, IsDefault: true}
+	// Параллельно её может создать другой запрос — тогда вставка ничего не делает
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).Create(&c).Error; err != nil {
+		return 0, err
+	}
+	if c.ID != 0 {
+		return c.ID, nil
+	}
+	err := tx.Select("id").Where("user_id = ? AND is_default", userID).First(&c).Error
+	return c.ID, err
+}
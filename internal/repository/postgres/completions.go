@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type completionRepo struct {
+	db *gorm.DB
+}
+
+func (r *completionRepo) Add(ctx context.Context, completion *models.ActivityCompletion) error {
+	return r.db.WithContext(ctx).Omit("Activity").Create(completion).Error
+}
+
+func (r *completionRepo) ListSince(ctx context.Context, userID uint, from time.Time) ([]models.ActivityCompletion, error) {
+	completions := []models.ActivityCompletion{}
+	err := r.db.WithContext(ctx).Preload("Activity").
+		Where("user_id = ? AND completed_at >= ?", userID, from).
+		Order("completed_at desc, id desc").Find(&completions).Error
+	if err != nil {
+		return nil, err
+	}
+	return completions, nil
+}
+
+func (r *completionRepo) Each(ctx context.Context, userID uint, fn func(models.ActivityCompletion) error) error {
+	return each(r.db.WithContext(ctx).Preload("Activity").Where("user_id = ?", userID), fn)
+}
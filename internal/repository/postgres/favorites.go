@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type favoriteRepo struct {
+	db *gorm.DB
+}
+
+func (r *favoriteRepo) List(ctx context.Context, userID uint, order repository.ActivityOrder, page repository.Page) ([]models.Activity, int64, error) {
+	var total int64
+	if err := r.favorites(ctx, userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	activities := []models.Activity{}
+	q := r.favorites(ctx, userID).Select("activities.*, collection_items.created_at AS favorited_at")
+	if err := paginate(orderActivities(q, order), page).Find(&activities).Error; err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
+
+// favorites — неудалённые активности из коллекции по умолчанию пользователя
+func (r *favoriteRepo) favorites(ctx context.Context, userID uint) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.Activity{}).
+		Joins("JOIN collection_items ON collection_items.activity_id = activities.id").
+		Joins("JOIN collections ON collections.id = collection_items.collection_id AND collections.is_default AND collections.user_id = ?", userID)
+}
+
+// defaultID — подзапрос с id коллекции по умолчанию пользователя
+func (r *favoriteRepo) defaultID(userID uint) *gorm.DB {
+	return r.db.Model(&models.Collection{}).Select("id").Where("user_id = ? AND is_default", userID)
+}
+
+func (r *favoriteRepo) Add(ctx context.Context, userID, activityID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		collectionID, err := defaultCollection(tx, userID)
+		if err != nil {
+			return err
+		}
+		return addItem(tx, &models.CollectionItem{CollectionID: collectionID, ActivityID: activityID})
+	})
+}
+
+func (r *favoriteRepo) Remove(ctx context.Context, userID, activityID uint) error {
+	return r.db.WithContext(ctx).
+		Where("collection_id IN (?) AND activity_id = ?", r.defaultID(userID), activityID).
+		Delete(&models.CollectionItem{}).Error
+}
+
+func (r *favoriteRepo) Favorited(ctx context.Context, userID uint, activityIDs []uint) ([]uint, error) {
+	ids := []uint{}
+	if len(activityIDs) == 0 {
+		return ids, nil
+	}
+	err := r.db.WithContext(ctx).Model(&models.CollectionItem{}).
+		Where("collection_id IN (?) AND activity_id IN ?", r.defaultID(userID), activityIDs).
+		Pluck("activity_id", &ids).Error
+	return ids, err
+}
+
+func (r *favoriteRepo) Each(ctx context.Context, userID uint, fn func(models.Activity) error) error {
+	return each(r.favorites(ctx, userID).Select("activities.*, collection_items.created_at AS favorited_at"), fn)
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type historyRepo struct {
+	db *gorm.DB
+}
+
+func (r *historyRepo) List(ctx context.Context, userID uint, filter repository.HistoryFilter, page repository.Page) ([]models.History, int64, error) {
+	views := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&models.History{}).
+			Joins("JOIN activities ON activities.id = histories.activity_id AND activities.deleted_at IS NULL").
+			Where("histories.user_id = ?", userID)
+		q = withPeriod(q, filter)
+		if filter.Distinct {
+			latest := r.db.WithContext(ctx).Model(&models.History{}).Select("DISTINCT ON (activity_id) id").Where("user_id = ?", userID)
+			latest = withPeriod(latest, filter).Order("activity_id, viewed_at DESC, id DESC")
+			q = q.Where("histories.id IN (?)", latest)
+		}
+		return q
+	}
+	var total int64
+	if err := views().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	history := []models.History{}
+	q := views().Preload("Activity").Order("histories.viewed_at DESC, histories.id DESC")
+	if err := paginate(q, page).Find(&history).Error; err != nil {
+		return nil, 0, err
+	}
+	return history, total, nil
+}
+
+// withPeriod ограничивает просмотры периодом из filter
+func withPeriod(q *gorm.DB, filter repository.HistoryFilter) *gorm.DB {
+	if !filter.From.IsZero() {
+		q = q.Where("histories.viewed_at >= ?", filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		q = q.Where("histories.viewed_at < ?", filter.To.UTC())
+	}
+	return q
+}
+
+func (r *historyRepo) Add(ctx context.Context, entry *models.History) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *historyRepo) Delete(ctx context.Context, userID, id uint) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.History{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *historyRepo) Clear(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.History{}).Error
+}
+
+func (r *historyRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Where("viewed_at < ?", before).Delete(&models.History{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *historyRepo) ListSince(ctx context.Context, userID uint, from time.Time) ([]models.History, error) {
+	history := []models.History{}
+	err := r.db.WithContext(ctx).Where("user_id = ? AND viewed_at >= ?", userID, from).Order("viewed_at desc").Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+func (r *historyRepo) Each(ctx context.Context, userID uint, fn func(models.History) error) error {
+	return each(r.db.WithContext(ctx).Preload("Activity").Where("user_id = ?", userID), fn)
+}
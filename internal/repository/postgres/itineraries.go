@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type itineraryRepo struct {
+	db *gorm.DB
+}
+
+func (r *itineraryRepo) Create(ctx context.Context, it *models.Itinerary) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(it).Error; err != nil {
+			return err
+		}
+		for i := range it.Items {
+			it.Items[i].ItineraryID = it.ID
+		}
+		if len(it.Items) == 0 {
+			return nil
+		}
+		// Сами активности уже есть в базе, сохраняем только пункты
+		return tx.Omit("Activity").Create(&it.Items).Error
+	})
+}
+
+func (r *itineraryRepo) List(ctx context.Context, userID uint, page repository.Page) ([]models.Itinerary, int64, error) {
+	var total int64
+	if err := r.db.WithContext(ctx).Model(&models.Itinerary{}).Where("user_id = ?", userID).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	itineraries := []models.Itinerary{}
+	q := r.withItems(r.db.WithContext(ctx)).Where("user_id = ?", userID).Order("created_at desc, id desc")
+	if err := paginate(q, page).Find(&itineraries).Error; err != nil {
+		return nil, 0, err
+	}
+	return itineraries, total, nil
+}
+
+func (r *itineraryRepo) Get(ctx context.Context, userID, id uint) (*models.Itinerary, error) {
+	var it models.Itinerary
+	if err := r.withItems(r.db.WithContext(ctx)).Where("id = ? AND user_id = ?", id, userID).First(&it).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &it, nil
+}
+
+func (r *itineraryRepo) Delete(ctx context.Context, userID, id uint) error {
+	res := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Itinerary{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// withItems подгружает пункты по порядку; удалённые активности остаются nil
+func (r *itineraryRepo) withItems(q *gorm.DB) *gorm.DB {
+	return q.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position")
+	}).Preload("Items.Activity")
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type moodStatRepo struct {
+	db *gorm.DB
+}
+
+func (r *moodStatRepo) Add(ctx context.Context, entry *models.MoodEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *moodStatRepo) LatestOn(ctx context.Context, userID uint, date time.Time) (*models.MoodEntry, error) {
+	var entry models.MoodEntry
+	err := r.db.WithContext(ctx).Where("user_id = ? AND date = ?", userID, date).
+		Order("logged_at desc, id desc").First(&entry).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &entry, nil
+}
+
+func (r *moodStatRepo) Update(ctx context.Context, entry *models.MoodEntry) error {
+	res := r.db.WithContext(ctx).Model(&models.MoodEntry{}).Where("id = ? AND user_id = ?", entry.ID, entry.UserID).
+		Select("mood", "intensity", "note", "tags").Updates(entry)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *moodStatRepo) DeleteDay(ctx context.Context, userID uint, date time.Time) error {
+	res := r.db.WithContext(ctx).Where("user_id = ? AND date = ?", userID, date).Delete(&models.MoodEntry{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *moodStatRepo) ListEntries(ctx context.Context, userID uint, from, to time.Time) ([]models.MoodEntry, error) {
+	entries := []models.MoodEntry{}
+	err := between(r.db.WithContext(ctx).Where("user_id = ?", userID), from, to).
+		Order("date asc, logged_at asc, id asc").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *moodStatRepo) ListDays(ctx context.Context, userID uint, from, to time.Time) ([]models.MoodStat, error) {
+	entries := between(r.db.WithContext(ctx).Model(&models.MoodEntry{}).Where("user_id = ?", userID), from, to)
+	stats := []models.MoodStat{}
+	err := r.db.WithContext(ctx).Raw(`SELECT DISTINCT ON (date) id, user_id, date, mood,
+			ROUND(AVG(intensity) OVER (PARTITION BY date), 1) AS intensity,
+			COUNT(*) OVER (PARTITION BY date) AS entries
+		FROM (?) AS e
+		ORDER BY date ASC, logged_at DESC, id DESC`, entries).Scan(&stats).Error
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *moodStatRepo) LoggedDays(ctx context.Context, userID uint) ([]time.Time, error) {
+	days := []time.Time{}
+	err := r.db.WithContext(ctx).Model(&models.MoodEntry{}).Where("user_id = ?", userID).
+		Distinct().Order("date asc").Pluck("date", &days).Error
+	if err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// between ограничивает записи днями from..to; нулевой to — без верхней границы
+func between(q *gorm.DB, from, to time.Time) *gorm.DB {
+	q = q.Where("date >= ?", from)
+	if !to.IsZero() {
+		q = q.Where("date <= ?", to)
+	}
+	return q
+}
+
+func (r *moodStatRepo) EachEntry(ctx context.Context, userID uint, fn func(models.MoodEntry) error) error {
+	return each(r.db.WithContext(ctx).Where("user_id = ?", userID), fn)
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type moodRepo struct {
+	db *gorm.DB
+}
+
+func (r *moodRepo) List(ctx context.Context) ([]models.Mood, error) {
+	moods := []models.Mood{}
+	if err := r.db.WithContext(ctx).Order("position, slug").Find(&moods).Error; err != nil {
+		return nil, err
+	}
+	return moods, nil
+}
+
+func (r *moodRepo) Create(ctx context.Context, mood *models.Mood) error {
+	return translate(r.db.WithContext(ctx).Create(mood).Error)
+}
+
+func (r *moodRepo) Update(ctx context.Context, mood *models.Mood) error {
+	res := r.db.WithContext(ctx).Model(&models.Mood{}).Where("slug = ?", mood.Slug).
+		Select("label_ru", "label_en", "emoji", "color", "position", "valence").Updates(mood)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
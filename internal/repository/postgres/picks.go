@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+type pickRepo struct {
+	db *gorm.DB
+}
+
+func (r *pickRepo) Add(ctx context.Context, pick *models.ActivityPick) error {
+	return r.db.WithContext(ctx).Create(pick).Error
+}
+
+func (r *pickRepo) Recent(ctx context.Context, userID uint, limit int) ([]models.ActivityPick, error) {
+	picks := []models.ActivityPick{}
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("picked_at desc, id desc").Limit(limit).Find(&picks).Error
+	if err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
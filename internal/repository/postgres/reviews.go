@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type reviewRepo struct {
+	db *gorm.DB
+}
+
+func (r *reviewRepo) Upsert(ctx context.Context, review *models.Review) (bool, error) {
+	created := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockActivity(tx, review.ActivityID); err != nil {
+			return err
+		}
+		var existing models.Review
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND activity_id = ?", review.UserID, review.ActivityID).
+			First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(review).Error; err != nil {
+				return translate(err)
+			}
+			created = true
+		case err != nil:
+			return err
+		default:
+			review.ID, review.Hidden, review.CreatedAt = existing.ID, existing.Hidden, existing.CreatedAt
+			review.UpdatedAt = time.Now()
+			err := tx.Model(&existing).
+				Updates(map[string]any{"rating": review.Rating, "text": review.Text, "updated_at": review.UpdatedAt}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return updateRating(tx, review.ActivityID)
+	})
+	return created, err
+}
+
+func (r *reviewRepo) Get(ctx context.Context, userID, activityID uint) (*models.Review, error) {
+	var review models.Review
+	err := r.withAuthor(r.db.WithContext(ctx)).
+		Where("reviews.user_id = ? AND reviews.activity_id = ?", userID, activityID).
+		First(&review).Error
+	if err != nil {
+		return nil, translate(err)
+	}
+	return &review, nil
+}
+
+func (r *reviewRepo) Delete(ctx context.Context, userID, activityID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockActivity(tx, activityID); err != nil {
+			return err
+		}
+		res := tx.Where("user_id = ? AND activity_id = ?", userID, activityID).Delete(&models.Review{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return repository.ErrNotFound
+		}
+		return updateRating(tx, activityID)
+	})
+}
+
+func (r *reviewRepo) List(ctx context.Context, activityID uint, includeHidden bool, page repository.Page) ([]models.Review, int64, error) {
+	reviews := func() *gorm.DB {
+		q := r.db.WithContext(ctx).Model(&models.Review{}).Where("reviews.activity_id = ?", activityID)
+		if !includeHidden {
+			q = q.Where("NOT reviews.hidden")
+		}
+		return q
+	}
+	var total int64
+	if err := reviews().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	list := []models.Review{}
+	q := r.withAuthor(reviews()).Order("reviews.created_at DESC, reviews.id DESC")
+	if err := paginate(q, page).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+	return list, total, nil
+}
+
+func (r *reviewRepo) SetHidden(ctx context.Context, id uint, hidden bool) (*models.Review, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Активность отзыва не меняется, поэтому её можно узнать до блокировки
+		var review models.Review
+		if err := tx.Select("activity_id").First(&review, id).Error; err != nil {
+			return translate(err)
+		}
+		if err := lockActivity(tx, review.ActivityID); err != nil {
+			return err
+		}
+		res := tx.Model(&models.Review{}).Where("id = ?", id).Update("hidden", hidden)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// Отзыв удалили, пока ждали блокировку
+			return repository.ErrNotFound
+		}
+		return updateRating(tx, review.ActivityID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var review models.Review
+	if err := r.withAuthor(r.db.WithContext(ctx)).Where("reviews.id = ?", id).First(&review).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &review, nil
+}
+
+// withAuthor добавляет к отзывам имя автора
+func (r *reviewRepo) withAuthor(q *gorm.DB) *gorm.DB {
+	return q.Select("reviews.*, users.username").Joins("JOIN users ON users.id = reviews.user_id")
+}
+
+// lockActivity блокирует строку активности до конца транзакции. Берётся до изменения
+// отзывов, чтобы параллельные правки отзывов одной активности шли по очереди и
+// updateRating не записал рейтинг, посчитанный без чужого отзыва
+func lockActivity(tx *gorm.DB, activityID uint) error {
+	var activity models.Activity
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&activity, activityID).Error
+	return translate(err)
+}
+
+// updateRating пересчитывает среднюю оценку и число видимых отзывов активности;
+// вызывать после lockActivity
+func updateRating(tx *gorm.DB, activityID uint) error {
+	return tx.Exec(`UPDATE activities SET
+		rating_avg = COALESCE((SELECT ROUND(AVG(rating), 2) FROM reviews WHERE activity_id = ? AND NOT hidden), 0),
+		rating_count = (SELECT COUNT(*) FROM reviews WHERE activity_id = ? AND NOT hidden)
+		WHERE id = ?`, activityID, activityID, activityID).Error
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type searchEventRepo struct {
+	db *gorm.DB
+}
+
+func (r *searchEventRepo) Add(ctx context.Context, event *models.SearchEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *searchEventRepo) ListSince(ctx context.Context, userID uint, from time.Time) ([]models.SearchEvent, error) {
+	events := []models.SearchEvent{}
+	err := r.db.WithContext(ctx).Where("user_id = ? AND created_at >= ?", userID, from).Order("created_at desc, id desc").Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *searchEventRepo) Stats(ctx context.Context, from time.Time, limit int) (repository.SearchStats, error) {
+	stats := repository.SearchStats{TopQueries: []repository.TermCount{}, TopMoods: []repository.TermCount{}, ZeroResults: []repository.TermCount{}}
+	db := r.db.WithContext(ctx)
+	var totals struct{ Total, Users int64 }
+	err := db.Model(&models.SearchEvent{}).Where("created_at >= ?", from).
+		Select("COUNT(*) AS total, COUNT(DISTINCT user_id) AS users").Scan(&totals).Error
+	if err != nil {
+		return stats, err
+	}
+	stats.Total, stats.Users = totals.Total, totals.Users
+	queries := `SELECT lower(query) AS term, COUNT(*) AS count FROM search_events
+		WHERE created_at >= ? AND query <> '' %s
+		GROUP BY lower(query) ORDER BY count DESC, term LIMIT ?`
+	if err := db.Raw(fmt.Sprintf(queries, ""), from, limit).Scan(&stats.TopQueries).Error; err != nil {
+		return stats, err
+	}
+	if err := db.Raw(fmt.Sprintf(queries, "AND result_count = 0"), from, limit).Scan(&stats.ZeroResults).Error; err != nil {
+		return stats, err
+	}
+	err = db.Raw(`SELECT m AS term, COUNT(*) AS count FROM search_events, unnest(moods) AS m
+		WHERE created_at >= ?
+		GROUP BY m ORDER BY count DESC, term LIMIT ?`, from, limit).Scan(&stats.TopMoods).Error
+	return stats, err
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type sessionRepo struct {
+	db *gorm.DB
+}
+
+func (r *sessionRepo) Create(ctx context.Context, session *models.Session, token *models.RefreshToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(session).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *sessionRepo) IsActive(ctx context.Context, id string) (bool, error) {
+	var active int64
+	err := r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Count(&active).Error
+	return active > 0, err
+}
+
+func (r *sessionRepo) Revoke(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *sessionRepo) Rotate(ctx context.Context, tokenHash string, next *models.RefreshToken, now time.Time) error {
+	reused := false
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var token models.RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", tokenHash).
+			First(&token).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repository.ErrTokenInvalid
+		}
+		if err != nil {
+			return err
+		}
+		var session models.Session
+		if err := tx.First(&session, "id = ?", token.SessionID).Error; err != nil {
+			return err
+		}
+		if session.RevokedAt != nil {
+			return repository.ErrTokenInvalid
+		}
+		if token.UsedAt != nil {
+			// Повторное использование — отзываем всё семейство токенов.
+			// Возвращаем nil, чтобы транзакция с отзывом закоммитилась.
+			reused = true
+			return tx.Model(&session).Update("revoked_at", now).Error
+		}
+		if now.After(token.ExpiresAt) {
+			return repository.ErrTokenInvalid
+		}
+		if err := tx.Model(&token).Update("used_at", now).Error; err != nil {
+			return err
+		}
+		next.SessionID = token.SessionID
+		next.UserID = token.UserID
+		return tx.Create(next).Error
+	})
+	if err == nil && reused {
+		return repository.ErrTokenReused
+	}
+	return err
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+type shareRepo struct {
+	db *gorm.DB
+}
+
+func (r *shareRepo) Create(ctx context.Context, s *models.Share) error {
+	return translate(r.db.WithContext(ctx).Create(s).Error)
+}
+
+func (r *shareRepo) Get(ctx context.Context, slug string) (*models.Share, error) {
+	var share models.Share
+	if err := r.db.WithContext(ctx).Where("slug = ?", slug).First(&share).Error; err != nil {
+		return nil, translate(err)
+	}
+	return &share, nil
+}
+
+func (r *shareRepo) List(ctx context.Context, userID uint) ([]models.Share, error) {
+	shares := []models.Share{}
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC, id DESC").Find(&shares).Error
+	return shares, err
+}
+
+func (r *shareRepo) Revoke(ctx context.Context, userID uint, slug string) error {
+	res := r.db.WithContext(ctx).Model(&models.Share{}).
+		Where("slug = ? AND user_id = ?", slug, userID).
+		Update("revoked_at", gorm.Expr("COALESCE(revoked_at, ?)", time.Now()))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *shareRepo) AddView(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.Share{}).Where("id = ?", id).
+		UpdateColumn("view_count", gorm.Expr("view_count + 1")).Error
+}
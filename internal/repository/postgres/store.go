@@ -0,0 +1,66 @@
+// Package postgres реализует репозитории поверх gorm и PostgreSQL
+package postgres
+
+import (
+	"errors"
+
+	"github.com/zenrush/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+// batchSize — сколько строк читать за раз при обходе через Each
+const batchSize = 500
+
+// NewStore собирает репозитории поверх уже открытого соединения
+func NewStore(db *gorm.DB) *repository.Store {
+	return &repository.Store{
+		Users:        &userRepo{db: db},
+		Sessions:     &sessionRepo{db: db},
+		Activities:   &activityRepo{db: db},
+		Favorites:    &favoriteRepo{db: db},
+		History:      &historyRepo{db: db},
+		MoodStats:    &moodStatRepo{db: db},
+		Picks:        &pickRepo{db: db},
+		Itineraries:  &itineraryRepo{db: db},
+		Moods:        &moodRepo{db: db},
+		SearchEvents: &searchEventRepo{db: db},
+		Completions:  &completionRepo{db: db},
+		Collections:  &collectionRepo{db: db},
+		Shares:       &shareRepo{db: db},
+		Reviews:      &reviewRepo{db: db},
+	}
+}
+
+// translate приводит ошибки gorm к ошибкам пакета repository
+func translate(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return repository.ErrNotFound
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+func paginate(q *gorm.DB, page repository.Page) *gorm.DB {
+	if page.Limit > 0 {
+		q = q.Limit(page.Limit)
+	}
+	if page.Offset > 0 {
+		q = q.Offset(page.Offset)
+	}
+	return q
+}
+
+// each читает q пачками по batchSize и вызывает fn для каждой строки
+func each[T any](q *gorm.DB, fn func(T) error) error {
+	var batch []T
+	return q.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		for _, row := range batch {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
@@ -0,0 +1,34 @@
+// Package repository описывает хранилища данных, с которыми работают хендлеры.
+//
+// Реализация для PostgreSQL лежит в repository/postgres, in-memory реализация
+// (для тестов и запуска без БД) — в repository/memory.
+package repository
+
+import "errors"
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrTokenInvalid — refresh-токен неизвестен, истёк или его сессия отозвана
+	ErrTokenInvalid = errors.New("invalid refresh token")
+	// ErrTokenReused — refresh-токен уже был обменян; сессия при этом отзывается
+	ErrTokenReused = errors.New("refresh token reused")
+)
+
+// Store собирает все репозитории одного хранилища
+type Store struct {
+	Users        UserRepository
+	Sessions     SessionRepository
+	Activities   ActivityRepository
+	Favorites    FavoriteRepository
+	History      HistoryRepository
+	MoodStats    MoodStatRepository
+	Picks        PickRepository
+	Itineraries  ItineraryRepository
+	Moods        MoodRepository
+	SearchEvents SearchEventRepository
+	Completions  CompletionRepository
+	Collections  CollectionRepository
+	Shares       ShareRepository
+	Reviews      ReviewRepository
+}
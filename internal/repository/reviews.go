@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// ReviewRepository хранит отзывы и поддерживает рейтинг активности (Activity.RatingAvg и RatingCount)
+// в согласии с видимыми отзывами
+type ReviewRepository interface {
+	// Upsert создаёт отзыв пользователя об активности или меняет оценку и текст уже оставленного;
+	// возвращает true, если отзыв новый; нет активности — ErrNotFound. Скрытый отзыв после правки остаётся скрытым.
+	Upsert(ctx context.Context, review *models.Review) (bool, error)
+	// Get возвращает отзыв пользователя об активности; нет отзыва — ErrNotFound
+	Get(ctx context.Context, userID, activityID uint) (*models.Review, error)
+	// Delete удаляет отзыв пользователя; нет отзыва — ErrNotFound
+	Delete(ctx context.Context, userID, activityID uint) error
+	// List возвращает страницу отзывов об активности от новых к старым и их общее число;
+	// скрытые — только при includeHidden
+	List(ctx context.Context, activityID uint, includeHidden bool, page Page) ([]models.Review, int64, error)
+	// SetHidden скрывает отзыв или возвращает его; нет отзыва — ErrNotFound
+	SetHidden(ctx context.Context, id uint, hidden bool) (*models.Review, error)
+}
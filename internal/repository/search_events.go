@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+// TermCount — значение и сколько раз оно встретилось
+type TermCount struct {
+	Term  string `json:"term"`
+	Count int64  `json:"count"`
+}
+
+// SearchStats — сводка поисков за период
+type SearchStats struct {
+	Total       int64       `json:"total"`
+	Users       int64       `json:"users"` // Сколько разных пользователей искали
+	TopQueries  []TermCount `json:"top_queries"`
+	TopMoods    []TermCount `json:"top_moods"`
+	ZeroResults []TermCount `json:"zero_results"` // Запросы, по которым ничего не нашлось
+}
+
+type SearchEventRepository interface {
+	Add(ctx context.Context, event *models.SearchEvent) error
+	// ListSince возвращает поиски пользователя начиная с from, от новых к старым
+	ListSince(ctx context.Context, userID uint, from time.Time) ([]models.SearchEvent, error)
+	// Stats считает поиски всех пользователей начиная с from; в каждом топе до limit значений.
+	// Запросы сравниваются без учёта регистра.
+	Stats(ctx context.Context, from time.Time, limit int) (SearchStats, error)
+}
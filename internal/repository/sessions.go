@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type SessionRepository interface {
+	// Create сохраняет новую сессию вместе с её первым refresh-токеном
+	Create(ctx context.Context, session *models.Session, token *models.RefreshToken) error
+	// IsActive сообщает, существует ли сессия и не отозвана ли она
+	IsActive(ctx context.Context, id string) (bool, error)
+	Revoke(ctx context.Context, id string) error
+	// Rotate атомарно помечает токен с хешем tokenHash использованным и сохраняет
+	// next в той же сессии (SessionID и UserID заполняются из старого токена).
+	// Если токен уже был использован, сессия отзывается и возвращается ErrTokenReused.
+	Rotate(ctx context.Context, tokenHash string, next *models.RefreshToken, now time.Time) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type ShareRepository interface {
+	// Create возвращает ErrAlreadyExists, если такой slug уже занят
+	Create(ctx context.Context, s *models.Share) error
+	// Get находит ссылку по slug, в том числе истёкшую и отозванную; нет такой — ErrNotFound
+	Get(ctx context.Context, slug string) (*models.Share, error)
+	// List возвращает ссылки пользователя от новых к старым
+	List(ctx context.Context, userID uint) ([]models.Share, error)
+	// Revoke отзывает ссылку, повторный отзыв ничего не меняет; чужая ссылка — ErrNotFound
+	Revoke(ctx context.Context, userID uint, slug string) error
+	// AddView увеличивает счётчик просмотров
+	AddView(ctx context.Context, id uint) error
+}
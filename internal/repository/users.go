@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zenrush/backend/internal/models"
+)
+
+type UserRepository interface {
+	// Create возвращает ErrAlreadyExists, если имя пользователя занято
+	Create(ctx context.Context, user *models.User) error
+	GetByID(ctx context.Context, id uint) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	// UpdateProfile сохраняет изменяемые пользователем поля профиля
+	UpdateProfile(ctx context.Context, user *models.User) error
+}
@@ -0,0 +1,36 @@
+// Package retention периодически удаляет данные, которые больше не нужно хранить
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/zenrush/backend/internal/repository"
+)
+
+// PruneHistory удаляет просмотры старше maxAge сразу и затем каждые interval,
+// пока не отменён ctx. Ошибки только логируются: следующая попытка — через interval.
+func PruneHistory(ctx context.Context, history repository.HistoryRepository, maxAge, interval time.Duration) {
+	prune := func() {
+		deleted, err := history.DeleteBefore(ctx, time.Now().Add(-maxAge))
+		if err != nil {
+			log.Printf("prune history: %v", err)
+			return
+		}
+		if deleted > 0 {
+			log.Printf("prune history: deleted %d views older than %s", deleted, maxAge)
+		}
+	}
+	prune()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			prune()
+		}
+	}
+}
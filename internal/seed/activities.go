@@ -0,0 +1,70 @@
+package seed
+
+import (
+	"github.com/lib/pq"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+// people — указатель на верхнюю границу размера компании
+func people(n int) *int {
+	return &n
+}
+
+// activities — примеры активностей, создаются при первом запуске на пустой базе
+var activities = []models.Activity{
+	// Бесплатные активности
+	{Name: "Прогулка в парке", Description: "Приятная прогулка на свежем воздухе", Budget: 0, Time: 2, Weather: pq.StringArray{weather.Sunny, weather.Cloudy}, MinPeople: 1, Moods: pq.StringArray{"neutral", "good", "cheerful"}},
+	{Name: "Чтение книги", Description: "Уютно устроиться с интересной книгой", Budget: 0, Time: 3, Weather: pq.StringArray{weather.Cloudy, weather.Rainy}, MinPeople: 1, MaxPeople: people(1), Moods: pq.StringArray{"calm", "inspired"}},
+	{Name: "Медитация", Description: "Расслабляющая медитация для души", Budget: 0, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(1), Moods: pq.StringArray{"calm", "inspired"}},
+	{Name: "Йога дома", Description: "Утренняя практика для бодрости", Budget: 0, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(2), Moods: pq.StringArray{"calm", "inspired"}},
+	{Name: "Рисование", Description: "Творческий процесс с красками", Budget: 0, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(2), Moods: pq.StringArray{"inspired", "calm"}},
+	{Name: "Прослушивание музыки", Description: "Любимые треки для настроения", Budget: 0, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"cheerful", "calm"}},
+	{Name: "Фотографирование", Description: "Съёмка интересных моментов", Budget: 0, Time: 2, Weather: pq.StringArray{weather.Sunny, weather.Cloudy, weather.Snowy}, MinPeople: 1, MaxPeople: people(3), Moods: pq.StringArray{"inspired", "cheerful"}},
+	{Name: "Вечерняя прогулка", Description: "Романтичная прогулка под звёздами", Budget: 0, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 2, MaxPeople: people(2), Moods: pq.StringArray{"romantic", "calm"}},
+	{Name: "Пикник на природе", Description: "Отдых на свежем воздухе", Budget: 0, Time: 4, Weather: pq.StringArray{weather.Sunny}, MinPeople: 2, Moods: pq.StringArray{"cheerful", "friendly"}},
+	{Name: "Написание дневника", Description: "Запись мыслей и планов", Budget: 0, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(1), Moods: pq.StringArray{"calm", "inspired"}},
+
+	// Недорогие активности (до 500₽)
+	{Name: "Кофе с другом", Description: "Встретиться и поболтать за чашкой кофе", Budget: 300, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 2, MaxPeople: people(4), Moods: pq.StringArray{"cheerful", "friendly"}},
+	{Name: "Посещение музея", Description: "Культурное просвещение", Budget: 400, Time: 3, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"inspired", "curious"}},
+	{Name: "Кино в кинотеатре", Description: "Новый фильм на большом экране", Budget: 500, Time: 3, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"cheerful", "curious"}},
+	{Name: "Боулинг", Description: "Активная игра с друзьями", Budget: 400, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 2, MaxPeople: people(8), Moods: pq.StringArray{"cheerful", "active"}},
+	{Name: "Лазертаг", Description: "Захватывающая командная игра", Budget: 450, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 4, Moods: pq.StringArray{"active", "cheerful"}},
+	{Name: "Квест-комната", Description: "Интеллектуальное развлечение", Budget: 500, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 2, MaxPeople: people(6), Moods: pq.StringArray{"curious", "cheerful"}},
+	{Name: "Мастер-класс по рисованию", Description: "Творческое развитие", Budget: 400, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"inspired", "curious"}},
+	{Name: "Скалодром", Description: "Активный спорт для всех", Budget: 350, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"active", "inspired"}},
+	{Name: "Бильярд", Description: "Классическая игра для компании", Budget: 300, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 2, MaxPeople: people(4), Moods: pq.StringArray{"cheerful", "friendly"}},
+	{Name: "Настольные игры", Description: "Интеллектуальное развлечение", Budget: 200, Time: 3, Weather: pq.StringArray{weather.Any}, MinPeople: 2, MaxPeople: people(8), Moods: pq.StringArray{"cheerful", "curious"}},
+
+	// Средние активности (500-1500₽)
+	{Name: "Ресторан", Description: "Ужин в хорошем ресторане", Budget: 1200, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 2, Moods: pq.StringArray{"romantic", "cheerful"}},
+	{Name: "СПА-салон", Description: "Расслабляющие процедуры", Budget: 1500, Time: 3, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(2), Moods: pq.StringArray{"calm", "romantic"}},
+	{Name: "Концерт", Description: "Живая музыка и эмоции", Budget: 1000, Time: 4, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"cheerful", "inspired"}},
+	{Name: "Театр", Description: "Классическое искусство", Budget: 800, Time: 4, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"inspired", "curious"}},
+	{Name: "Картинг", Description: "Скорость и адреналин", Budget: 800, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"active", "cheerful"}},
+	{Name: "Пейнтбол", Description: "Командная игра на природе", Budget: 600, Time: 3, Weather: pq.StringArray{weather.Sunny, weather.Cloudy}, MinPeople: 4, Moods: pq.StringArray{"active", "cheerful"}},
+	{Name: "Верёвочный парк", Description: "Активный отдых на высоте", Budget: 700, Time: 3, Weather: pq.StringArray{weather.Sunny}, MinPeople: 1, Moods: pq.StringArray{"active", "inspired"}},
+	{Name: "Массаж", Description: "Расслабляющий массаж", Budget: 1000, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(1), Moods: pq.StringArray{"calm", "romantic"}},
+	{Name: "Кулинарный мастер-класс", Description: "Обучение готовке", Budget: 800, Time: 3, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"curious", "inspired"}},
+	{Name: "Экскурсия по городу", Description: "Познавательная прогулка", Budget: 600, Time: 4, Weather: pq.StringArray{weather.Sunny, weather.Cloudy}, MinPeople: 1, Moods: pq.StringArray{"curious", "inspired"}},
+
+	// Дорогие активности (1500₽+)
+	{Name: "Прыжок с парашютом", Description: "Экстремальные эмоции", Budget: 5000, Time: 4, Weather: pq.StringArray{weather.Sunny}, MinPeople: 1, Moods: pq.StringArray{"active", "inspired"}},
+	{Name: "Полёт на воздушном шаре", Description: "Романтичное приключение", Budget: 8000, Time: 3, Weather: pq.StringArray{weather.Sunny}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"romantic", "inspired"}},
+	{Name: "Дайвинг", Description: "Исследование подводного мира", Budget: 3000, Time: 5, Weather: pq.StringArray{weather.Sunny}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"active", "curious"}},
+	{Name: "Сёрфинг", Description: "Покорение волн", Budget: 2500, Time: 4, Weather: pq.StringArray{weather.Sunny}, MinPeople: 1, Moods: pq.StringArray{"active", "inspired"}},
+	{Name: "Горные лыжи", Description: "Зимний спорт", Budget: 4000, Time: 6, Weather: pq.StringArray{weather.Snowy, weather.Sunny}, MinPeople: 1, Moods: pq.StringArray{"active", "cheerful"}},
+	{Name: "Сноуборд", Description: "Экстремальный зимний спорт", Budget: 3500, Time: 5, Weather: pq.StringArray{weather.Snowy, weather.Sunny}, MinPeople: 1, Moods: pq.StringArray{"active", "inspired"}},
+	{Name: "Вертолётная экскурсия", Description: "Вид на город с высоты", Budget: 6000, Time: 2, Weather: pq.StringArray{weather.Sunny}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"inspired", "romantic"}},
+	{Name: "Баня с друзьями", Description: "Традиционный отдых", Budget: 2000, Time: 4, Weather: pq.StringArray{weather.Any}, MinPeople: 2, Moods: pq.StringArray{"cheerful", "friendly"}},
+	{Name: "Рыбалка", Description: "Спокойный отдых на природе", Budget: 1500, Time: 6, Weather: pq.StringArray{weather.Sunny, weather.Cloudy}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"calm", "curious"}},
+	{Name: "Охота", Description: "Активный отдых в лесу", Budget: 3000, Time: 8, Weather: pq.StringArray{weather.Sunny, weather.Cloudy, weather.Snowy}, MinPeople: 2, MaxPeople: people(6), Moods: pq.StringArray{"active", "curious"}},
+
+	// Домашние активности
+	{Name: "Готовка нового блюда", Description: "Кулинарные эксперименты", Budget: 500, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"curious", "inspired"}},
+	{Name: "Просмотр сериала", Description: "Уютный вечер дома", Budget: 0, Time: 3, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(4), Moods: pq.StringArray{"calm", "cheerful"}},
+	{Name: "Уборка и организация", Description: "Приведение дома в порядок", Budget: 0, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, Moods: pq.StringArray{"calm", "inspired"}},
+	{Name: "Игра на музыкальном инструменте", Description: "Творческое самовыражение", Budget: 0, Time: 1, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(1), Moods: pq.StringArray{"inspired", "calm"}},
+	{Name: "Вязание или рукоделие", Description: "Создание чего-то своими руками", Budget: 200, Time: 2, Weather: pq.StringArray{weather.Any}, MinPeople: 1, MaxPeople: people(1), Moods: pq.StringArray{"calm", "inspired"}},
+}
@@ -0,0 +1,18 @@
+package seed
+
+import "github.com/zenrush/backend/internal/models"
+
+// moodDictionary повторяет словарь из миграций 0009_moods и 0012_mood_valence
+var moodDictionary = []models.Mood{
+	{Slug: "cheerful", LabelRu: "Весело", LabelEn: "Cheerful", Emoji: "😄", Color: "#FFC107", Position: 1, Valence: 1},
+	{Slug: "calm", LabelRu: "Спокойно", LabelEn: "Calm", Emoji: "😌", Color: "#4FC3F7", Position: 2, Valence: 1},
+	{Slug: "inspired", LabelRu: "Вдохновенно", LabelEn: "Inspired", Emoji: "✨", Color: "#BA68C8", Position: 3, Valence: 1},
+	{Slug: "neutral", LabelRu: "Нейтрально", LabelEn: "Neutral", Emoji: "😐", Color: "#B0BEC5", Position: 4, Valence: 0},
+	{Slug: "good", LabelRu: "Хорошо", LabelEn: "Good", Emoji: "🙂", Color: "#81C784", Position: 5, Valence: 1},
+	{Slug: "romantic", LabelRu: "Романтично", LabelEn: "Romantic", Emoji: "💕", Color: "#F06292", Position: 6, Valence: 1},
+	{Slug: "friendly", LabelRu: "Дружелюбно", LabelEn: "Friendly", Emoji: "🤗", Color: "#FFB74D", Position: 7, Valence: 1},
+	{Slug: "curious", LabelRu: "Интересно", LabelEn: "Curious", Emoji: "🧐", Color: "#7986CB", Position: 8, Valence: 0},
+	{Slug: "active", LabelRu: "Активно", LabelEn: "Active", Emoji: "⚡", Color: "#FF7043", Position: 9, Valence: 0},
+	{Slug: "sad", LabelRu: "Грустно", LabelEn: "Sad", Emoji: "😢", Color: "#90A4AE", Position: 10, Valence: -1},
+	{Slug: "relaxed", LabelRu: "Расслабленно", LabelEn: "Relaxed", Emoji: "🛋️", Color: "#A1887F", Position: 11, Valence: 1},
+}
@@ -0,0 +1,99 @@
+// Package seed создаёт начальные данные: админа, словарь настроений, примеры активностей и
+// статистику настроения админа. Работает через репозитории, поэтому
+// подходит и для PostgreSQL, и для in-memory хранилища.
+package seed
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/zenrush/backend/internal/localtime"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Run(ctx context.Context, store *repository.Store, timezone *time.Location) error {
+	log.Println("Начинаю создание начальных данных...")
+
+	admin, err := store.Users.GetByUsername(ctx, "admin")
+	if errors.Is(err, repository.ErrNotFound) {
+		log.Println("Создаю пользователя admin...")
+		hash, err := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
+		if err != nil {
+			log.Printf("Ошибка хеширования пароля: %v", err)
+			return err
+		}
+		admin = &models.User{Username: "admin", PasswordHash: string(hash), Role: "admin"}
+		if err := store.Users.Create(ctx, admin); err != nil {
+			log.Printf("Ошибка создания админа: %v", err)
+			return err
+		}
+		log.Println("Админ создан успешно")
+	} else if err != nil {
+		log.Printf("Ошибка проверки существования админа: %v", err)
+		return err
+	} else {
+		log.Println("Админ уже существует")
+	}
+
+	// Словарь настроений в PostgreSQL заполняет миграция, здесь — для in-memory хранилища
+	dictionary, err := store.Moods.List(ctx)
+	if err != nil {
+		log.Printf("Ошибка чтения словаря настроений: %v", err)
+		return err
+	}
+	if len(dictionary) == 0 {
+		log.Println("Создаю словарь настроений...")
+		for i := range moodDictionary {
+			m := moodDictionary[i]
+			if err := store.Moods.Create(ctx, &m); err != nil {
+				log.Printf("Ошибка создания настроения %s: %v", m.Slug, err)
+				return err
+			}
+		}
+	}
+
+	// Проверяем количество активностей
+	activityCount, err := store.Activities.Count(ctx)
+	if err != nil {
+		log.Printf("Ошибка подсчёта активностей: %v", err)
+		return err
+	}
+	if activityCount == 0 {
+		log.Println("Создаю примеры активностей...")
+		for i := range activities {
+			a := activities[i]
+			if err := store.Activities.Create(ctx, &a); err != nil {
+				log.Printf("Ошибка создания активности %d: %v", i+1, err)
+				return err
+			}
+		}
+		log.Printf("Создано %d активностей", len(activities))
+	} else {
+		log.Printf("Активности уже существуют (%d штук)", activityCount)
+	}
+
+	// --- Сидим дневник настроения для admin на 7 дней ---
+	moods := []string{"cheerful", "sad", "calm", "inspired", "neutral", "active", "relaxed"}
+	now := time.Now()
+	today := localtime.Day(now, timezone)
+	entries, err := store.MoodStats.ListEntries(ctx, admin.ID, today.AddDate(0, 0, -len(moods)+1), today)
+	if err != nil {
+		log.Printf("Ошибка чтения дневника настроения: %v", err)
+		return err
+	}
+	if len(entries) == 0 {
+		for i, mood := range moods {
+			entry := models.MoodEntry{UserID: admin.ID, Date: today.AddDate(0, 0, -i), LoggedAt: now.AddDate(0, 0, -i), Mood: mood, Intensity: 3}
+			if err := store.MoodStats.Add(ctx, &entry); err != nil {
+				log.Printf("Ошибка создания статистики настроения: %v", err)
+			}
+		}
+	}
+
+	log.Println("Начальные данные созданы успешно")
+	return nil
+}
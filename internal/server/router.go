@@ -0,0 +1,135 @@
+// Package server собирает HTTP-роутер API поверх заданного хранилища
+package server
+
+import (
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/handlers"
+	"github.com/zenrush/backend/internal/middleware"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/weather"
+)
+
+// NewRouter собирает роутер; forecast может быть nil — тогда weather=auto не фильтрует по погоде.
+// timezone — часовой пояс для пользователей, которые свой не указали.
+func NewRouter(store *repository.Store, forecast weather.Provider, timezone *time.Location) *gin.Engine {
+	r := gin.Default()
+
+	// Настройка CORS для фронтенда
+	config := cors.DefaultConfig()
+	config.AllowOrigins = []string{"http://127.0.0.1:5500", "http://localhost:5173", "http://localhost:3000", "http://localhost:4173"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Timezone"}
+	config.ExposeHeaders = []string{"X-Total-Count", "Link", "X-Weather", "Content-Disposition"}
+	config.AllowCredentials = true
+	r.Use(cors.New(config))
+
+	authHandler := handlers.NewAuthHandler(store.Users, store.Sessions)
+	activityHandler := handlers.NewActivityHandler(store.Activities, store.SearchEvents, store.Users, forecast, store.Moods)
+	favoriteHandler := handlers.NewFavoriteHandler(store.Favorites, store.Activities)
+	collectionHandler := handlers.NewCollectionHandler(store.Collections, store.Activities)
+	shareHandler := handlers.NewShareHandler(store.Shares, store.Collections, store.Activities)
+	reviewHandler := handlers.NewReviewHandler(store.Reviews, store.Activities)
+	historyHandler := handlers.NewHistoryHandler(store.History, store.Users, timezone)
+	moodStatHandler := handlers.NewMoodStatHandler(store.MoodStats, store.Moods, store.Users, timezone)
+	recommendationHandler := handlers.NewRecommendationHandler(store.Activities, store.Favorites, store.History, store.MoodStats, store.SearchEvents, store.Completions, store.Picks, store.Users, forecast, store.Moods)
+	itineraryHandler := handlers.NewItineraryHandler(store.Itineraries, store.Activities, store.Favorites, store.History, store.MoodStats, store.SearchEvents, store.Completions, store.Users, forecast, store.Moods)
+	userHandler := handlers.NewUserHandler(store.Users)
+	moodHandler := handlers.NewMoodHandler(store.Moods)
+	analyticsHandler := handlers.NewAnalyticsHandler(store.SearchEvents)
+	completionHandler := handlers.NewCompletionHandler(store.Completions, store.Activities, store.Moods)
+	exportHandler := handlers.NewExportHandler(store.MoodStats, store.History, store.Favorites, store.Completions)
+	jwtAuth := middleware.JWTAuth(store.Sessions)
+
+	api := r.Group("/api")
+	{
+		auth := api.Group("/auth")
+		auth.POST("/register", authHandler.Register)
+		auth.POST("/login", authHandler.Login)
+		auth.POST("/refresh", authHandler.Refresh)
+		auth.POST("/logout", jwtAuth, authHandler.Logout)
+
+		activities := api.Group("/activities")
+		activities.Use(jwtAuth)
+		activities.GET("", activityHandler.List)
+		activities.POST("", activityHandler.Create)
+		activities.GET("/suggest", activityHandler.Suggest)
+		activities.GET("/random", recommendationHandler.Random)
+		activities.GET(":id", activityHandler.Get)
+		activities.PUT(":id", activityHandler.Update)
+		activities.DELETE(":id", activityHandler.Delete)
+		activities.POST(":id/complete", completionHandler.Complete)
+		activities.POST(":id/share", shareHandler.ShareActivity)
+		activities.GET(":id/reviews", reviewHandler.List)
+		activities.GET(":id/review", reviewHandler.Mine)
+		activities.PUT(":id/review", reviewHandler.Save)
+		activities.DELETE(":id/review", reviewHandler.Delete)
+
+		api.POST("/reviews/:id/hide", jwtAuth, reviewHandler.Hide)
+		api.POST("/reviews/:id/unhide", jwtAuth, reviewHandler.Unhide)
+
+		favorites := api.Group("/favorites")
+		favorites.Use(jwtAuth)
+		favorites.GET("", favoriteHandler.List)
+		favorites.GET("check", favoriteHandler.Check)
+		favorites.POST(":activity_id", favoriteHandler.Add)
+		favorites.DELETE(":activity_id", favoriteHandler.Remove)
+
+		collections := api.Group("/collections")
+		collections.Use(jwtAuth)
+		collections.GET("", collectionHandler.List)
+		collections.POST("", collectionHandler.Create)
+		collections.GET(":id", collectionHandler.Get)
+		collections.PUT(":id", collectionHandler.Update)
+		collections.DELETE(":id", collectionHandler.Delete)
+		collections.POST(":id/items", collectionHandler.AddItem)
+		collections.PUT(":id/items/:activity_id", collectionHandler.UpdateItem)
+		collections.DELETE(":id/items/:activity_id", collectionHandler.RemoveItem)
+		collections.PUT(":id/order", collectionHandler.Reorder)
+		collections.POST(":id/share", shareHandler.ShareCollection)
+
+		api.GET("/shares", jwtAuth, shareHandler.List)
+		api.DELETE("/shares/:slug", jwtAuth, shareHandler.Revoke)
+		// Публичные ссылки открываются без авторизации
+		api.GET("/public/shares/:slug", shareHandler.View)
+
+		history := api.Group("/history")
+		history.Use(jwtAuth)
+		history.GET("", historyHandler.List)
+		history.POST(":activity_id", historyHandler.Add)
+		history.DELETE("", historyHandler.Clear)
+		history.DELETE(":id", historyHandler.Delete)
+
+		api.GET("/recommendations", jwtAuth, recommendationHandler.List)
+
+		itineraries := api.Group("/itineraries")
+		itineraries.Use(jwtAuth)
+		itineraries.GET("", itineraryHandler.List)
+		itineraries.POST("", itineraryHandler.Create)
+		itineraries.GET(":id", itineraryHandler.Get)
+		itineraries.DELETE(":id", itineraryHandler.Delete)
+
+		// Словарь настроений открыт без авторизации, менять его могут moderator/admin
+		api.GET("/moods", moodHandler.List)
+		api.POST("/moods", jwtAuth, moodHandler.Create)
+		api.PUT("/moods/:slug", jwtAuth, moodHandler.Update)
+
+		api.GET("/users/me", jwtAuth, userHandler.Me)
+		api.PUT("/users/me", jwtAuth, userHandler.UpdateMe)
+
+		// --- Mood stats ---
+		api.POST("/mood-stats", jwtAuth, moodStatHandler.SaveOrUpdate)
+		api.PUT("/mood-stats/:date", jwtAuth, moodStatHandler.Update)
+		api.DELETE("/mood-stats/:date", jwtAuth, moodStatHandler.Delete)
+		api.GET("/users/me/mood-stats", jwtAuth, moodStatHandler.List)
+		api.GET("/users/me/mood-stats/summary", jwtAuth, moodStatHandler.Summary)
+		api.GET("/users/me/insights", jwtAuth, completionHandler.Insights)
+		api.GET("/users/me/export", jwtAuth, exportHandler.Export)
+
+		api.GET("/analytics/search", jwtAuth, analyticsHandler.Search)
+	}
+
+	return r
+}
@@ -0,0 +1,414 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/zenrush/backend/internal/handlers"
+	"github.com/zenrush/backend/internal/models"
+	"github.com/zenrush/backend/internal/moodstats"
+	"github.com/zenrush/backend/internal/recommend"
+	"github.com/zenrush/backend/internal/repository"
+	"github.com/zenrush/backend/internal/repository/memory"
+	"github.com/zenrush/backend/internal/server"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newRouter — роутер поверх пустого in-memory хранилища, без погоды, в UTC
+func newRouter(t *testing.T) (*gin.Engine, *repository.Store) {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "test-secret")
+	store := memory.NewStore()
+	return server.NewRouter(store, nil, time.UTC), store
+}
+
+func do(t *testing.T, r http.Handler, method, path, token string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+	return v
+}
+
+// login регистрирует пользователя и возвращает его токены
+func login(t *testing.T, r http.Handler, username string) handlers.LoginResponse {
+	t.Helper()
+	creds := handlers.RegisterRequest{Username: username, Password: "secret123"}
+	if w := do(t, r, http.MethodPost, "/api/auth/register", "", creds); w.Code != http.StatusCreated {
+		t.Fatalf("register: %d %s", w.Code, w.Body)
+	}
+	w := do(t, r, http.MethodPost, "/api/auth/login", "", creds)
+	if w.Code != http.StatusOK {
+		t.Fatalf("login: %d %s", w.Code, w.Body)
+	}
+	return decode[handlers.LoginResponse](t, w)
+}
+
+// authorized — пускает ли API с этим access-токеном
+func authorized(t *testing.T, r http.Handler, token string) int {
+	t.Helper()
+	return do(t, r, http.MethodGet, "/api/favorites", token, nil).Code
+}
+
+func TestAuthFlow(t *testing.T) {
+	r, _ := newRouter(t)
+	tokens := login(t, r, "alice")
+	if tokens.Token == "" || tokens.RefreshToken == "" || tokens.ExpiresIn <= 0 {
+		t.Fatalf("login response = %+v", tokens)
+	}
+
+	creds := handlers.RegisterRequest{Username: "alice", Password: "secret123"}
+	if w := do(t, r, http.MethodPost, "/api/auth/register", "", creds); w.Code != http.StatusBadRequest {
+		t.Errorf("register twice: got %d, want 400", w.Code)
+	}
+	wrong := handlers.LoginRequest{Username: "alice", Password: "wrong-password"}
+	if w := do(t, r, http.MethodPost, "/api/auth/login", "", wrong); w.Code != http.StatusUnauthorized {
+		t.Errorf("wrong password: got %d, want 401", w.Code)
+	}
+	if code := authorized(t, r, ""); code != http.StatusUnauthorized {
+		t.Errorf("no token: got %d, want 401", code)
+	}
+	if code := authorized(t, r, tokens.Token); code != http.StatusOK {
+		t.Fatalf("with token: got %d", code)
+	}
+
+	w := do(t, r, http.MethodPost, "/api/auth/refresh", "", handlers.RefreshRequest{RefreshToken: tokens.RefreshToken})
+	if w.Code != http.StatusOK {
+		t.Fatalf("refresh: got %d %s", w.Code, w.Body)
+	}
+	refreshed := decode[handlers.LoginResponse](t, w)
+	if refreshed.RefreshToken == tokens.RefreshToken {
+		t.Error("refresh returned the same refresh token")
+	}
+	if code := authorized(t, r, refreshed.Token); code != http.StatusOK {
+		t.Errorf("with refreshed token: got %d", code)
+	}
+
+	if w := do(t, r, http.MethodPost, "/api/auth/logout", refreshed.Token, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("logout: got %d %s", w.Code, w.Body)
+	}
+	// После выхода не работают ни access-токены сессии, ни её refresh-токен
+	for _, token := range []string{tokens.Token, refreshed.Token} {
+		if code := authorized(t, r, token); code != http.StatusUnauthorized {
+			t.Errorf("after logout: got %d, want 401", code)
+		}
+	}
+	w = do(t, r, http.MethodPost, "/api/auth/refresh", "", handlers.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after logout: got %d, want 401", w.Code)
+	}
+}
+
+func TestRefreshReuseRevokesSession(t *testing.T) {
+	r, _ := newRouter(t)
+	tokens := login(t, r, "bob")
+	body := handlers.RefreshRequest{RefreshToken: tokens.RefreshToken}
+	w := do(t, r, http.MethodPost, "/api/auth/refresh", "", body)
+	if w.Code != http.StatusOK {
+		t.Fatalf("refresh: got %d %s", w.Code, w.Body)
+	}
+	refreshed := decode[handlers.LoginResponse](t, w)
+
+	// Повторное предъявление использованного токена отзывает всю сессию
+	if w := do(t, r, http.MethodPost, "/api/auth/refresh", "", body); w.Code != http.StatusUnauthorized {
+		t.Fatalf("reused refresh token: got %d, want 401", w.Code)
+	}
+	if code := authorized(t, r, refreshed.Token); code != http.StatusUnauthorized {
+		t.Errorf("after reuse: got %d, want 401", code)
+	}
+	w = do(t, r, http.MethodPost, "/api/auth/refresh", "", handlers.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after reuse: got %d, want 401", w.Code)
+	}
+}
+
+// seedActivities создаёт словарь из двух настроений и пять активностей с id 1–5
+func seedActivities(t *testing.T, store *repository.Store) {
+	t.Helper()
+	ctx := context.Background()
+	for _, m := range []models.Mood{
+		{Slug: "calm", LabelRu: "Спокойное", LabelEn: "Calm", Valence: 1},
+		{Slug: "cheerful", LabelRu: "Весёлое", LabelEn: "Cheerful", Valence: 1},
+	} {
+		if err := store.Moods.Create(ctx, &m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, a := range []models.Activity{
+		{Name: "Прогулка в парке", Budget: 0, Time: 2, Weather: []string{"sunny"}, MinPeople: 1, Moods: []string{"calm"}},
+		{Name: "Кино", Budget: 600, Time: 3, Weather: []string{"any"}, MinPeople: 1, MaxPeople: ptr(2), Moods: []string{"cheerful"}},
+		{Name: "Настольные игры", Budget: 300, Time: 3, Weather: []string{"any"}, MinPeople: 2, MaxPeople: ptr(6), Moods: []string{"cheerful", "calm"}},
+		{Name: "Чтение", Budget: 0, Time: 1, Weather: []string{"any"}, MinPeople: 1, MaxPeople: ptr(1), Moods: []string{"calm"}},
+		{Name: "Картинг", Budget: 2000, Time: 2, Weather: []string{"sunny", "cloudy"}, MinPeople: 2, Moods: []string{"cheerful"}},
+	} {
+		if err := store.Activities.Create(ctx, &a); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func ptr(v int) *int {
+	return &v
+}
+
+func ids(activities []models.Activity) []uint {
+	result := make([]uint, len(activities))
+	for i, a := range activities {
+		result[i] = a.ID
+	}
+	return result
+}
+
+func TestListActivities(t *testing.T) {
+	r, store := newRouter(t)
+	seedActivities(t, store)
+	token := login(t, r, "carol").Token
+
+	tests := []struct {
+		name      string
+		query     string
+		wantIDs   []uint
+		wantTotal string
+		wantLink  string
+	}{
+		{"all", "", []uint{1, 2, 3, 4, 5}, "5", ""},
+		{"first page", "?per_page=2", []uint{1, 2}, "5", `</api/activities?page=2&per_page=2>; rel="next"`},
+		{"middle page", "?per_page=2&page=2", []uint{3, 4}, "5", `</api/activities?page=3&per_page=2>; rel="next", </api/activities?page=1&per_page=2>; rel="prev"`},
+		{"last page", "?per_page=2&page=3", []uint{5}, "5", `</api/activities?page=2&per_page=2>; rel="prev"`},
+		{"past the end", "?per_page=2&page=4", []uint{}, "5", `</api/activities?page=3&per_page=2>; rel="prev"`},
+		{"max budget", "?max_budget=300", []uint{1, 3, 4}, "3", ""},
+		{"budget range", "?min_budget=300&max_budget=1000", []uint{2, 3}, "2", ""},
+		{"max time", "?max_time=2", []uint{1, 4, 5}, "3", ""},
+		{"time is max time", "?time=2", []uint{1, 4, 5}, "3", ""},
+		{"min time", "?min_time=3", []uint{2, 3}, "2", ""},
+		{"exact time", "?min_time=2&max_time=2", []uint{1, 5}, "2", ""},
+		{"mood any", "?mood=calm", []uint{1, 3, 4}, "3", ""},
+		{"mood all", "?mood=calm,cheerful&mood_match=all", []uint{3}, "1", ""},
+		{"mood by label", "?mood=Весёлое", []uint{2, 3, 5}, "3", ""},
+		{"weather", "?weather=cloudy", []uint{2, 3, 4, 5}, "4", ""},
+		{"several weather conditions", "?weather=rainy,cloudy", []uint{2, 3, 4, 5}, "4", ""},
+		{"people", "?people_count=4", []uint{1, 3, 5}, "3", ""},
+		{"alone", "?people_count=1", []uint{1, 2, 4}, "3", ""},
+		{"several filters", "?mood=cheerful&max_budget=1000", []uint{2, 3}, "2", ""},
+		{"filtered page", "?mood=calm&per_page=2&page=2", []uint{4}, "3", `</api/activities?mood=calm&page=1&per_page=2>; rel="prev"`},
+		{"sort by budget desc", "?sort=budget&order=desc&per_page=3", []uint{5, 2, 3}, "5", `</api/activities?order=desc&page=2&per_page=3&sort=budget>; rel="next"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, r, http.MethodGet, "/api/activities"+tt.query, token, nil)
+			if w.Code != http.StatusOK {
+				t.Fatalf("got %d %s", w.Code, w.Body)
+			}
+			if got := ids(decode[[]models.Activity](t, w)); !slices.Equal(got, tt.wantIDs) {
+				t.Errorf("ids = %v, want %v", got, tt.wantIDs)
+			}
+			if total := w.Header().Get("X-Total-Count"); total != tt.wantTotal {
+				t.Errorf("X-Total-Count = %q, want %q", total, tt.wantTotal)
+			}
+			if link := w.Header().Get("Link"); link != tt.wantLink {
+				t.Errorf("Link = %q, want %q", link, tt.wantLink)
+			}
+		})
+	}
+
+	for _, query := range []string{"?page=0", "?per_page=101", "?sort=rank", "?order=up", "?mood=unknown", "?weather=foggy"} {
+		if w := do(t, r, http.MethodGet, "/api/activities"+query, token, nil); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want 400", query, w.Code)
+		}
+	}
+	if w := do(t, r, http.MethodGet, "/api/activities", "", nil); w.Code != http.StatusUnauthorized {
+		t.Errorf("without token: got %d, want 401", w.Code)
+	}
+}
+
+func TestFavorites(t *testing.T) {
+	r, store := newRouter(t)
+	seedActivities(t, store)
+	token := login(t, r, "dave").Token
+
+	// Повторное добавление идемпотентно
+	steps := []struct {
+		method, path string
+		want         int
+	}{
+		{http.MethodPost, "/api/favorites/2", http.StatusCreated},
+		{http.MethodPost, "/api/favorites/2", http.StatusOK},
+		{http.MethodPost, "/api/favorites/99", http.StatusNotFound},
+		{http.MethodPost, "/api/favorites/abc", http.StatusBadRequest},
+	}
+	for _, s := range steps {
+		if w := do(t, r, s.method, s.path, token, nil); w.Code != s.want {
+			t.Errorf("%s %s: got %d, want %d (%s)", s.method, s.path, w.Code, s.want, w.Body)
+		}
+	}
+
+	w := do(t, r, http.MethodGet, "/api/favorites", token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("list favorites: got %d %s", w.Code, w.Body)
+	}
+	favorites := decode[[]models.Activity](t, w)
+	if got := ids(favorites); !slices.Equal(got, []uint{2}) {
+		t.Fatalf("favorites = %v, want [2]", got)
+	}
+	if favorites[0].FavoritedAt == nil {
+		t.Error("favorited_at is not set")
+	}
+
+	w = do(t, r, http.MethodGet, "/api/favorites/check?ids=1,2", token, nil)
+	if got := decode[map[string]bool](t, w); !got["2"] || got["1"] {
+		t.Errorf("check = %v, want 2 only", got)
+	}
+
+	// Избранное — это коллекция по умолчанию, и повтор не добавил туда дубль
+	w = do(t, r, http.MethodGet, "/api/collections", token, nil)
+	collections := decode[[]models.Collection](t, w)
+	if len(collections) != 1 || !collections[0].IsDefault || collections[0].ItemCount != 1 {
+		t.Errorf("collections = %+v, want one default collection with one item", collections)
+	}
+
+	// Чужое избранное не видно
+	other := login(t, r, "erin").Token
+	w = do(t, r, http.MethodGet, "/api/favorites", other, nil)
+	if got := ids(decode[[]models.Activity](t, w)); len(got) != 0 {
+		t.Errorf("other user's favorites = %v, want none", got)
+	}
+
+	if w := do(t, r, http.MethodDelete, "/api/favorites/2", token, nil); w.Code != http.StatusNoContent {
+		t.Fatalf("remove: got %d %s", w.Code, w.Body)
+	}
+	w = do(t, r, http.MethodGet, "/api/favorites", token, nil)
+	if got := ids(decode[[]models.Activity](t, w)); len(got) != 0 {
+		t.Errorf("favorites after remove = %v, want none", got)
+	}
+}
+
+func TestRandomActivity(t *testing.T) {
+	pick := func(t *testing.T, r http.Handler, token, query string) uint {
+		t.Helper()
+		w := do(t, r, http.MethodGet, "/api/activities/random"+query, token, nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("random%s: got %d %s", query, w.Code, w.Body)
+		}
+		return decode[recommend.Recommendation](t, w).Activity.ID
+	}
+
+	// С одинаковым seed и одинаковыми данными выбор одинаковый
+	var first []uint
+	for i := 0; i < 2; i++ {
+		r, store := newRouter(t)
+		seedActivities(t, store)
+		token := login(t, r, "frank").Token
+		var got []uint
+		for _, seed := range []string{"1", "2", "3"} {
+			got = append(got, pick(t, r, token, "?seed="+seed))
+		}
+		if i == 0 {
+			first = got
+		} else if !slices.Equal(got, first) {
+			t.Errorf("same seeds: picked %v, then %v", first, got)
+		}
+	}
+
+	r, store := newRouter(t)
+	seedActivities(t, store)
+	ctx := context.Background()
+	if err := store.Activities.Create(ctx, &models.Activity{Name: "Йога", Time: 1, Weather: []string{"any"}, MinPeople: 1}); err != nil {
+		t.Fatal(err)
+	}
+	token := login(t, r, "grace").Token
+
+	// Активностей шесть, последние пять выборов не повторяются: первые шесть
+	// выборов разные, а седьмой — единственная не выбранная за последние пять
+	var picked []uint
+	for i := 0; i < 6; i++ {
+		id := pick(t, r, token, "?seed=42")
+		if slices.Contains(picked, id) {
+			t.Fatalf("pick %d: %d repeats one of the last picks %v", i+1, id, picked)
+		}
+		picked = append(picked, id)
+	}
+	if id := pick(t, r, token, "?seed=42"); id != picked[0] {
+		t.Errorf("pick 7: got %d, want %d", id, picked[0])
+	}
+
+	// Если подходит одна активность, она выбирается, даже если была недавно
+	for i := 0; i < 2; i++ {
+		if id := pick(t, r, token, "?max_budget=0&time=1&mood=calm"); id != 4 {
+			t.Errorf("only match: got %d, want 4", id)
+		}
+	}
+
+	if w := do(t, r, http.MethodGet, "/api/activities/random?seed=abc", token, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid seed: got %d, want 400", w.Code)
+	}
+	if w := do(t, r, http.MethodGet, "/api/activities/random?max_budget=0&mood=cheerful", token, nil); w.Code != http.StatusNotFound {
+		t.Errorf("no match: got %d, want 404", w.Code)
+	}
+}
+
+func TestMoodSummaryStreaksOutsidePeriod(t *testing.T) {
+	r, store := newRouter(t)
+	token := login(t, r, "frank").Token
+	ctx := context.Background()
+	user, err := store.Users.GetByUsername(ctx, "frank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 30 дней подряд по вчера и более ранняя серия из 40 дней — обе длиннее периода сводки
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	var days []int
+	for n := 1; n <= 30; n++ {
+		days = append(days, n)
+	}
+	for n := 50; n < 90; n++ {
+		days = append(days, n)
+	}
+	for _, n := range days {
+		d := today.AddDate(0, 0, -n)
+		if err := store.MoodStats.Add(ctx, &models.MoodEntry{UserID: user.ID, Date: d, LoggedAt: d, Mood: "calm", Intensity: 3}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := do(t, r, http.MethodGet, "/api/users/me/mood-stats/summary?days=7&trend_days=3", token, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("summary: got %d %s", w.Code, w.Body)
+	}
+	summary := decode[moodstats.Summary](t, w)
+	if summary.Total != 6 {
+		t.Errorf("total = %d, want 6 entries within the period", summary.Total)
+	}
+	if summary.Streaks.Current != 30 || summary.Streaks.Longest != 40 {
+		t.Errorf("streaks = %+v, want current 30 and longest 40", summary.Streaks)
+	}
+}
@@ -0,0 +1,122 @@
+package weather
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable — не удалось узнать погоду
+var ErrUnavailable = errors.New("weather unavailable")
+
+// Provider сообщает текущую погоду в городе одним из условий Sunny, Cloudy, Rainy, Snowy
+type Provider interface {
+	Current(ctx context.Context, city string) (string, error)
+}
+
+// HTTPProvider получает погоду из сервиса, совместимого с форматом j1 от wttr.in:
+// GET {BaseURL}/{city}?format=j1
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPProvider создаёт провайдер; client может быть nil — тогда с таймаутом 5 секунд
+func NewHTTPProvider(baseURL string, client *http.Client) *HTTPProvider {
+	if client == nil {
+		client = &http.Client{Timeout: 5 * time.Second}
+	}
+	return &HTTPProvider{baseURL: strings.TrimRight(baseURL, "/"), client: client}
+}
+
+type j1Response struct {
+	CurrentCondition []struct {
+		WeatherCode string `json:"weatherCode"`
+	} `json:"current_condition"`
+}
+
+func (p *HTTPProvider) Current(ctx context.Context, city string) (string, error) {
+	u := p.baseURL + "/" + url.PathEscape(city) + "?format=j1"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: status %d", ErrUnavailable, resp.StatusCode)
+	}
+	var body j1Response
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	if len(body.CurrentCondition) == 0 {
+		return "", fmt.Errorf("%w: no current condition", ErrUnavailable)
+	}
+	code, err := strconv.Atoi(body.CurrentCondition[0].WeatherCode)
+	if err != nil {
+		return "", fmt.Errorf("%w: bad weather code %q", ErrUnavailable, body.CurrentCondition[0].WeatherCode)
+	}
+	return fromCode(code), nil
+}
+
+// fromCode переводит код погоды WorldWeatherOnline (его отдаёт wttr.in) в наше условие
+func fromCode(code int) string {
+	switch code {
+	case 113:
+		return Sunny
+	case 116, 119, 122, 143, 248, 260:
+		return Cloudy
+	case 179, 227, 230, 323, 326, 329, 332, 335, 338, 368, 371, 392, 395:
+		return Snowy
+	}
+	return Rainy
+}
+
+// CachedProvider запоминает погоду по каждому городу на ttl; ошибки не кэшируются
+type CachedProvider struct {
+	next Provider
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	condition string
+	expires   time.Time
+}
+
+func NewCachedProvider(next Provider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{next: next, ttl: ttl, now: time.Now, entries: make(map[string]cacheEntry)}
+}
+
+func (p *CachedProvider) Current(ctx context.Context, city string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(city))
+	p.mu.Lock()
+	e, ok := p.entries[key]
+	p.mu.Unlock()
+	if ok && p.now().Before(e.expires) {
+		return e.condition, nil
+	}
+	condition, err := p.next.Current(ctx, city)
+	if err != nil {
+		return "", err
+	}
+	p.mu.Lock()
+	p.entries[key] = cacheEntry{condition: condition, expires: p.now().Add(p.ttl)}
+	p.mu.Unlock()
+	return condition, nil
+}
@@ -0,0 +1,159 @@
+package weather
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// j1 — ответ wttr.in в формате j1 с одним текущим условием (лишние поля опущены)
+func j1(code string) string {
+	return fmt.Sprintf(`{"current_condition":[{"temp_C":"21","weatherCode":%q,"weatherDesc":[{"value":"Partly cloudy"}]}],"nearest_area":[]}`, code)
+}
+
+func TestHTTPProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{"sunny", http.StatusOK, j1("113"), Sunny, false},
+		{"partly cloudy", http.StatusOK, j1("116"), Cloudy, false},
+		{"fog", http.StatusOK, j1("248"), Cloudy, false},
+		{"light rain", http.StatusOK, j1("296"), Rainy, false},
+		{"thunder", http.StatusOK, j1("389"), Rainy, false},
+		{"sleet", http.StatusOK, j1("179"), Snowy, false},
+		{"heavy snow", http.StatusOK, j1("338"), Snowy, false},
+		{"not found", http.StatusNotFound, "Unknown location", "", true},
+		{"server error", http.StatusInternalServerError, j1("113"), "", true},
+		{"malformed json", http.StatusOK, `{"current_condition":[`, "", true},
+		{"not json", http.StatusOK, "Sunny +21°C", "", true},
+		{"no current condition", http.StatusOK, `{"current_condition":[]}`, "", true},
+		{"bad weather code", http.StatusOK, j1("sunny"), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath, gotFormat string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath, gotFormat = r.URL.Path, r.URL.Query().Get("format")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer srv.Close()
+
+			got, err := NewHTTPProvider(srv.URL+"/", nil).Current(context.Background(), "Нижний Новгород")
+			if gotPath != "/Нижний Новгород" || gotFormat != "j1" {
+				t.Errorf("request: path %q, format %q", gotPath, gotFormat)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnavailable) {
+					t.Errorf("err = %v, want ErrUnavailable", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("got %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPProviderUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+	if _, err := NewHTTPProvider(url, nil).Current(context.Background(), "Moscow"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("err = %v, want ErrUnavailable", err)
+	}
+}
+
+// stubProvider отвечает по очереди значениями из answers и считает запросы по городам
+type stubProvider struct {
+	answers []string
+	err     error
+	calls   map[string]int
+}
+
+func (p *stubProvider) Current(ctx context.Context, city string) (string, error) {
+	if p.calls == nil {
+		p.calls = make(map[string]int)
+	}
+	p.calls[city]++
+	if p.err != nil {
+		return "", p.err
+	}
+	answer := p.answers[0]
+	if len(p.answers) > 1 {
+		p.answers = p.answers[1:]
+	}
+	return answer, nil
+}
+
+func TestCachedProviderTTL(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 7, 10, 12, 0, 0, 0, time.UTC)
+	next := &stubProvider{answers: []string{Sunny, Rainy, Cloudy}}
+	p := NewCachedProvider(next, 30*time.Minute)
+	p.now = func() time.Time { return now }
+
+	steps := []struct {
+		after time.Duration // сколько прошло с начала
+		city  string
+		want  string
+	}{
+		{0, "Moscow", Sunny},
+		{29 * time.Minute, "Moscow", Sunny},
+		{29 * time.Minute, " moscow ", Sunny}, // один город — одна запись в кэше
+		{30 * time.Minute, "Moscow", Rainy},
+		{59 * time.Minute, "Moscow", Rainy},
+		{61 * time.Minute, "Moscow", Cloudy},
+	}
+	start := now
+	for _, s := range steps {
+		now = start.Add(s.after)
+		got, err := p.Current(ctx, s.city)
+		if err != nil || got != s.want {
+			t.Errorf("+%v %q: got %q, %v; want %q", s.after, s.city, got, err, s.want)
+		}
+	}
+	if next.calls["Moscow"] != 3 || next.calls[" moscow "] != 0 {
+		t.Errorf("upstream calls = %v, want 3 for Moscow", next.calls)
+	}
+
+	// Другой город кэшируется отдельно
+	if got, _ := p.Current(ctx, "Kazan"); got != Cloudy || next.calls["Kazan"] != 1 {
+		t.Errorf("Kazan: got %q after %d calls", got, next.calls["Kazan"])
+	}
+}
+
+func TestCachedProviderDoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	next := &stubProvider{answers: []string{Snowy}, err: ErrUnavailable}
+	p := NewCachedProvider(next, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := p.Current(ctx, "Moscow"); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("call %d: err = %v, want ErrUnavailable", i+1, err)
+		}
+	}
+	if next.calls["Moscow"] != 2 {
+		t.Errorf("upstream calls = %d, want 2: errors must not be cached", next.calls["Moscow"])
+	}
+
+	// Как только сервис ответил, ответ кэшируется
+	next.err = nil
+	for i := 0; i < 2; i++ {
+		if got, err := p.Current(ctx, "Moscow"); err != nil || got != Snowy {
+			t.Fatalf("after recovery: got %q, %v", got, err)
+		}
+	}
+	if next.calls["Moscow"] != 3 {
+		t.Errorf("upstream calls = %d, want 3", next.calls["Moscow"])
+	}
+}
@@ -0,0 +1,96 @@
+// Package weather описывает погодные условия, для которых подходит активность.
+//
+// Активность хранит набор условий; Any означает «в любую погоду» и совпадает
+// с любым запрошенным условием.
+package weather
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+const (
+	Sunny  = "sunny"
+	Cloudy = "cloudy"
+	Rainy  = "rainy"
+	Snowy  = "snowy"
+	Any    = "any"
+)
+
+// Conditions — все допустимые значения в порядке хранения
+var Conditions = []string{Sunny, Cloudy, Rainy, Snowy, Any}
+
+var ErrUnknown = errors.New("unknown weather condition")
+
+// Valid проверяет, что s — известное условие
+func Valid(s string) bool {
+	return slices.Contains(Conditions, s)
+}
+
+// Normalize проверяет набор условий активности и приводит его к каноничному виду:
+// без повторов, в порядке Conditions; пустой набор или набор с Any — это {Any}
+func Normalize(set []string) ([]string, error) {
+	seen := make(map[string]bool, len(set))
+	for _, s := range set {
+		s = strings.ToLower(strings.TrimSpace(s))
+		if !Valid(s) {
+			return nil, ErrUnknown
+		}
+		seen[s] = true
+	}
+	if len(seen) == 0 || seen[Any] {
+		return []string{Any}, nil
+	}
+	result := make([]string, 0, len(seen))
+	for _, c := range Conditions {
+		if seen[c] {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// ParseQuery разбирает значения параметра weather: можно повторять параметр
+// и перечислять условия через запятую. Пустой результат или Any среди условий
+// означает, что погода не важна, — тогда возвращается nil.
+func ParseQuery(values []string) ([]string, error) {
+	var conditions []string
+	for _, v := range values {
+		for _, s := range strings.Split(v, ",") {
+			s = strings.ToLower(strings.TrimSpace(s))
+			if s == "" {
+				continue
+			}
+			if !Valid(s) {
+				return nil, ErrUnknown
+			}
+			if s == Any {
+				return nil, nil
+			}
+			if !slices.Contains(conditions, s) {
+				conditions = append(conditions, s)
+			}
+		}
+	}
+	return conditions, nil
+}
+
+// Matches сообщает, подходит ли активность с набором set хотя бы для одного
+// из условий conditions; пустой conditions подходит всегда
+func Matches(set []string, conditions []string) bool {
+	if len(conditions) == 0 || slices.Contains(set, Any) {
+		return true
+	}
+	for _, c := range conditions {
+		if slices.Contains(set, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Outdoor сообщает, зависит ли активность от погоды
+func Outdoor(set []string) bool {
+	return len(set) > 0 && !slices.Contains(set, Any)
+}